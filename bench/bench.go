@@ -0,0 +1,162 @@
+// Package bench times the registered solvers and compares the timings with
+// a saved baseline.
+package bench
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"testing"
+	"text/tabwriter"
+
+	"advent2022/input"
+	"advent2022/registry"
+)
+
+// Result is the timing of one part of one day.
+type Result struct {
+	Day         int   `json:"day"`
+	Part        int   `json:"part"`
+	NsPerOp     int64 `json:"ns_per_op"`
+	AllocsPerOp int64 `json:"allocs_per_op"`
+	BytesPerOp  int64 `json:"bytes_per_op"`
+}
+
+// Solver runs s on in b.N times.
+func Solver(b *testing.B, s registry.Solver, in string) {
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := s(in); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// File runs s on the input in the named file b.N times.
+func File(b *testing.B, s registry.Solver, name string) {
+	in, err := input.ReadFile(name)
+	if err != nil {
+		b.Fatal(err)
+	}
+	Solver(b, s, in)
+}
+
+// Run benchmarks every part of each of days, loading each day's input with
+// load.
+func Run(days []int, load func(day int) (string, error)) ([]Result, error) {
+	var results []Result
+	for _, day := range days {
+		parts := registry.Parts(day)
+		if len(parts) == 0 {
+			return nil, fmt.Errorf("day %v has no solvers", day)
+		}
+		in, err := load(day)
+		if err != nil {
+			return nil, err
+		}
+		for _, part := range parts {
+			s, _ := registry.Lookup(day, part)
+			// Check the solver once up front because a failure inside
+			// testing.Benchmark only shows up as an empty result.
+			if _, err := s(in); err != nil {
+				return nil, fmt.Errorf("day %v part %v: %v", day, part, err)
+			}
+			r := testing.Benchmark(func(b *testing.B) {
+				Solver(b, s, in)
+			})
+			results = append(results, Result{
+				Day:         day,
+				Part:        part,
+				NsPerOp:     r.NsPerOp(),
+				AllocsPerOp: r.AllocsPerOp(),
+				BytesPerOp:  r.AllocedBytesPerOp(),
+			})
+		}
+	}
+	return results, nil
+}
+
+// Regression is a result that is slower than its baseline.
+type Regression struct {
+	Result   Result
+	Baseline Result
+	// Change is the increase in ns/op as a percentage of the baseline.
+	Change float64
+}
+
+// Compare returns the results whose ns/op grew by more than threshold
+// percent over the matching baseline result. Results without a baseline are
+// skipped.
+func Compare(results, baseline []Result, threshold float64) []Regression {
+	base := map[[2]int]Result{}
+	for _, r := range baseline {
+		base[[2]int{r.Day, r.Part}] = r
+	}
+	var regressions []Regression
+	for _, r := range results {
+		b, ok := base[[2]int{r.Day, r.Part}]
+		if !ok || b.NsPerOp == 0 {
+			continue
+		}
+		change := change(r, b)
+		if change > threshold {
+			regressions = append(regressions, Regression{r, b, change})
+		}
+	}
+	return regressions
+}
+
+func change(r, baseline Result) float64 {
+	return float64(r.NsPerOp-baseline.NsPerOp) / float64(baseline.NsPerOp) * 100
+}
+
+// WriteTable writes results to w as an aligned table. If baseline is not nil,
+// each row also shows the change in ns/op against it.
+func WriteTable(w io.Writer, results, baseline []Result) error {
+	base := map[[2]int]Result{}
+	for _, r := range baseline {
+		base[[2]int{r.Day, r.Part}] = r
+	}
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', tabwriter.AlignRight)
+	header := "day\tpart\tns/op\tallocs/op\tB/op\t"
+	if baseline != nil {
+		header += "change\t"
+	}
+	fmt.Fprintln(tw, header)
+	for _, r := range results {
+		fmt.Fprintf(tw, "%v\t%v\t%v\t%v\t%v\t", r.Day, r.Part, r.NsPerOp, r.AllocsPerOp, r.BytesPerOp)
+		if baseline != nil {
+			if b, ok := base[[2]int{r.Day, r.Part}]; ok && b.NsPerOp != 0 {
+				fmt.Fprintf(tw, "%+.1f%%\t", change(r, b))
+			} else {
+				fmt.Fprint(tw, "-\t")
+			}
+		}
+		fmt.Fprintln(tw)
+	}
+	return tw.Flush()
+}
+
+// ReadBaseline reads results saved by WriteBaseline.
+func ReadBaseline(name string) ([]Result, error) {
+	b, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	var results []Result
+	if err := json.Unmarshal(b, &results); err != nil {
+		return nil, fmt.Errorf("parse baseline %v: %v", name, err)
+	}
+	return results, nil
+}
+
+// WriteBaseline saves results to the named file as JSON.
+func WriteBaseline(name string, results []Result) error {
+	b, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(name, append(b, '\n'), 0644)
+}
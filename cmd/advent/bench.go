@@ -0,0 +1,58 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"advent2022/bench"
+	"advent2022/input"
+	"advent2022/registry"
+)
+
+func runBench(args []string) error {
+	fs := flag.NewFlagSet("bench", flag.ExitOnError)
+	day := fs.Int("day", 0, "day to benchmark; 0 benchmarks every day")
+	fs.StringVar(&input.Dir, "inputs", input.Dir, "directory holding the inputs")
+	baseline := fs.String("baseline", "", "file of saved results to compare against")
+	save := fs.String("save", "", "file to save the results to")
+	threshold := fs.Float64("threshold", 10, "percent increase in ns/op that counts as a regression")
+	fs.Parse(args)
+	days := registry.Days()
+	if *day != 0 {
+		days = []int{*day}
+	}
+	results, err := bench.Run(days, func(day int) (string, error) {
+		return input.Load(day, "")
+	})
+	if err != nil {
+		return err
+	}
+	var base []bench.Result
+	if *baseline != "" {
+		base, err = bench.ReadBaseline(*baseline)
+		if err != nil {
+			return err
+		}
+	}
+	if err := bench.WriteTable(os.Stdout, results, base); err != nil {
+		return err
+	}
+	if *save != "" {
+		if err := bench.WriteBaseline(*save, results); err != nil {
+			return err
+		}
+	}
+	if *baseline == "" {
+		return nil
+	}
+	regressions := bench.Compare(results, base, *threshold)
+	for _, r := range regressions {
+		fmt.Printf("regression: day %v part %v went from %v to %v ns/op (%+.1f%%)\n",
+			r.Result.Day, r.Result.Part, r.Baseline.NsPerOp, r.Result.NsPerOp, r.Change)
+	}
+	if len(regressions) > 0 {
+		return fmt.Errorf("%v regressions over %v%%", len(regressions), *threshold)
+	}
+	return nil
+}
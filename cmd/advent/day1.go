@@ -0,0 +1,130 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"advent2022/day1"
+	"advent2022/input"
+)
+
+func runDay1(args []string) error {
+	fs := flag.NewFlagSet("day1", flag.ExitOnError)
+	path := fs.String("input", "", `path to the puzzle input, or "-" for stdin; defaults to inputs/day1.txt`)
+	k := fs.Int("top", 3, "number of elves carrying the most calories to report")
+	stats := fs.Bool("stats", false, "report statistics about the calories instead")
+	bins := fs.Int("bins", 10, "number of histogram bins in the statistics")
+	format := fs.String("format", "text", `format of the statistics, "text" or "json"`)
+	stream := fs.Bool("stream", false, "read the input line by line, keeping only the top elves in memory")
+	exact := fs.Bool("big", false, "count calories with arbitrary precision so that huge totals cannot overflow")
+	workers := fs.Int("workers", 0, "number of goroutines summing the calories; 0 sums them serially")
+	fs.Parse(args)
+	if err := exclusive(fs, "stream", "stats", "big", "workers"); err != nil {
+		return err
+	}
+	if *stream {
+		r, err := input.Open(1, *path)
+		if err != nil {
+			return err
+		}
+		defer r.Close()
+		elves, sum, err := day1.TopReader(r, *k)
+		if err != nil {
+			return err
+		}
+		printTop(elves, sum)
+		return nil
+	}
+	in, err := input.Load(1, *path)
+	if err != nil {
+		return err
+	}
+	if *stats {
+		s, err := day1.Statistics(in, *bins)
+		if err != nil {
+			return err
+		}
+		switch *format {
+		case "text":
+			return writeStats(os.Stdout, s)
+		case "json":
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			return enc.Encode(s)
+		}
+		return fmt.Errorf("format %q unrecognized", *format)
+	}
+	if *exact {
+		elves, sum, err := day1.TopBig(in, *k)
+		if err != nil {
+			return err
+		}
+		for _, elf := range elves {
+			fmt.Printf("elf %v: %v\n", elf.Index, elf.Calories)
+		}
+		fmt.Printf("total: %v\n", sum)
+		return nil
+	}
+	if *workers > 0 {
+		elves, sum, err := day1.TopParallel(in, *k, *workers)
+		if err != nil {
+			return err
+		}
+		printTop(elves, sum)
+		return nil
+	}
+	elves, sum, err := day1.Top(in, *k)
+	if err != nil {
+		return err
+	}
+	printTop(elves, sum)
+	return nil
+}
+
+func printTop(elves []day1.Elf, sum int) {
+	for _, elf := range elves {
+		fmt.Printf("elf %v: %v\n", elf.Index, elf.Calories)
+	}
+	fmt.Printf("total: %v\n", sum)
+}
+
+// histogramWidth is the length of the longest histogram bar.
+const histogramWidth = 40
+
+func writeStats(w io.Writer, s day1.Stats) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 1, ' ', 0)
+	fmt.Fprintf(tw, "elves\t%v\n", s.Elves)
+	fmt.Fprintf(tw, "items\t%v\n", s.Items)
+	fmt.Fprintf(tw, "min\t%v\n", s.Min)
+	fmt.Fprintf(tw, "max\t%v\n", s.Max)
+	fmt.Fprintf(tw, "mean\t%.1f\n", s.Mean)
+	fmt.Fprintf(tw, "median\t%.1f\n", s.Median)
+	fmt.Fprintf(tw, "p90\t%v\n", s.P90)
+	fmt.Fprintf(tw, "p99\t%v\n", s.P99)
+	var counts []string
+	for _, n := range s.ItemCounts {
+		counts = append(counts, fmt.Sprint(n))
+	}
+	fmt.Fprintf(tw, "items per elf\t%v\n", strings.Join(counts, " "))
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	var most int
+	for _, b := range s.Histogram {
+		if b.Count > most {
+			most = b.Count
+		}
+	}
+	fmt.Fprintln(w)
+	tw = tabwriter.NewWriter(w, 0, 8, 1, ' ', tabwriter.AlignRight)
+	for _, b := range s.Histogram {
+		bar := strings.Repeat("#", b.Count*histogramWidth/most)
+		fmt.Fprintf(tw, "%v\t-\t%v\t %-*v %v\n", b.Low, b.High, histogramWidth, bar, b.Count)
+	}
+	return tw.Flush()
+}
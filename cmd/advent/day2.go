@@ -0,0 +1,132 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"advent2022/day2"
+	"advent2022/input"
+)
+
+func runDay2(args []string) error {
+	fs := flag.NewFlagSet("day2", flag.ExitOnError)
+	path := fs.String("input", "", `path to the puzzle input, or "-" for stdin; defaults to inputs/day2.txt`)
+	guide := fs.String("guide", "both", `how to read the guide's second column: "shapes", "outcomes" or "both"`)
+	rulesPath := fs.String("rules", "", "JSON file of game rules; defaults to rock paper scissors")
+	trace := fs.Bool("trace", false, "print the score of every round")
+	asCSV := fs.Bool("csv", false, "print the trace as CSV")
+	optimize := fs.Bool("optimize", false, "print the best scoring guide against the opponent's moves")
+	maxWins := fs.Int("max-wins", day2.Unlimited, "most rounds the optimized guide may win; -1 is no limit")
+	maxRun := fs.Int("max-run", day2.Unlimited, "most rounds in a row the optimized guide may play one shape; -1 is no limit")
+	simulate := fs.Bool("simulate", false, "play the guide, random, frequency and last-move strategies against each other")
+	matches := fs.Int("matches", 1000, "number of matches each pair of strategies plays")
+	rounds := fs.Int("rounds", 100, "number of rounds in each match")
+	seed := fs.Int64("seed", 1, "seed of the random number generator")
+	strict := fs.Bool("strict", false, "accept only lines of exactly two symbols written as the rules give them")
+	fs.Parse(args)
+	in, err := input.Load(2, *path)
+	if err != nil {
+		return err
+	}
+	mode := day2.Lenient
+	if *strict {
+		mode = day2.Strict
+	}
+	var g day2.Guide
+	if *optimize {
+		g, err = day2.ParseOpponents(in)
+	} else {
+		g, err = day2.ParseGuide(in, mode)
+	}
+	if err != nil {
+		return err
+	}
+	rules := day2.Default
+	if *rulesPath != "" {
+		rules, err = day2.LoadRules(*rulesPath)
+		if err != nil {
+			return err
+		}
+	}
+	if *simulate {
+		guide, err := rules.FixedGuide(g)
+		if err != nil {
+			return err
+		}
+		strategies := []day2.Strategy{guide, day2.Random{}, day2.FrequencyCounter{}, day2.LastMoveBeater{}}
+		results, err := rules.Tournament(strategies, *matches, *rounds, *seed)
+		if err != nil {
+			return err
+		}
+		tw := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+		fmt.Fprintln(tw, "player\topponent\tmean\tvariance\topponent mean\topponent variance")
+		for _, m := range results {
+			fmt.Fprintf(tw, "%v\t%v\t%.1f\t%.1f\t%.1f\t%.1f\n", m.Names[0], m.Names[1], m.Mean[0], m.Variance[0], m.Mean[1], m.Variance[1])
+		}
+		return tw.Flush()
+	}
+	if *optimize {
+		total, guide, err := rules.Optimize(g, day2.Constraints{MaxWins: *maxWins, MaxRun: *maxRun})
+		if err != nil {
+			return err
+		}
+		fmt.Printf("total: %v\n%v\n", total, guide)
+		return nil
+	}
+	if *trace || *asCSV {
+		if *guide == "both" {
+			return fmt.Errorf(`-trace needs -guide "shapes" or "outcomes"`)
+		}
+		i, err := day2.ParseInterpretation(*guide)
+		if err != nil {
+			return err
+		}
+		steps, err := rules.Trace(g, i)
+		if err != nil {
+			return err
+		}
+		if *asCSV {
+			return day2.WriteCSV(os.Stdout, steps)
+		}
+		return writeTrace(os.Stdout, steps)
+	}
+	if *guide == "both" {
+		byShape, byOutcome, err := rules.Scores(g)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%v: %v\n", day2.Shapes, byShape)
+		fmt.Printf("%v: %v\n", day2.Outcomes, byOutcome)
+		return nil
+	}
+	i, err := day2.ParseInterpretation(*guide)
+	if err != nil {
+		return err
+	}
+	total, err := rules.Score(g, i)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%v: %v\n", i, total)
+	return nil
+}
+
+func writeTrace(w io.Writer, steps []day2.Step) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "line\topponent\toutcome\tshape\tshape score\toutcome score\ttotal")
+	for _, s := range steps {
+		fmt.Fprintf(tw, "%v\t%v\t%v\t%v\t%v\t%v\t%v\n", s.Line, s.Opponent, s.Outcome, s.Shape, s.ShapeScore, s.OutcomeScore, s.Total)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	var tally day2.Tally
+	if len(steps) > 0 {
+		tally = steps[len(steps)-1].Tally
+	}
+	_, err := fmt.Fprintf(w, "\nwins: %v, draws: %v, losses: %v\n", tally.Wins, tally.Draws, tally.Losses)
+	return err
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"advent2022/day3"
+	"advent2022/input"
+)
+
+func runDay3(args []string) error {
+	fs := flag.NewFlagSet("day3", flag.ExitOnError)
+	path := fs.String("input", "", `path to the puzzle input, or "-" for stdin; defaults to inputs/day3.txt`)
+	mode := fs.String("mode", "badges", `items to sum: "compartments" or "badges"`)
+	group := fs.Int("group", 3, "number of rucksacks per group in badges mode")
+	report := fs.Bool("report", false, "list every group's rucksacks, shared items and badge")
+	fs.Parse(args)
+	if err := exclusive(fs, "report", "mode"); err != nil {
+		return err
+	}
+	in, err := input.Load(3, *path)
+	if err != nil {
+		return err
+	}
+	if *report {
+		groups, err := day3.Report(in, *group)
+		if err != nil {
+			return err
+		}
+		return writeReport(os.Stdout, groups)
+	}
+	m, err := day3.ParseMode(*mode)
+	if err != nil {
+		return err
+	}
+	if m != day3.Badges && isSet(fs, "group") {
+		return fmt.Errorf("-group needs -mode badges")
+	}
+	var total int
+	if m == day3.Badges {
+		total, err = day3.BadgesOf(in, *group)
+	} else {
+		total, err = day3.Sum(in, m)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%v: %v\n", m, total)
+	return nil
+}
+
+func writeReport(w io.Writer, groups []day3.Group) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "group\tbadge\tpriority\tline\trucksack\tshared")
+	var flagged []string
+	var total int
+	for i, g := range groups {
+		badge := string(g.Common.Items())
+		if b, ok := g.Badge(); ok {
+			badge = string(b)
+		} else {
+			flagged = append(flagged, fmt.Sprintf("group %v: %v common items", i+1, g.Common.Len()))
+			if badge == "" {
+				badge = "-"
+			}
+		}
+		total += g.Priority()
+		for j, r := range g.Rucksacks {
+			if j == 0 {
+				fmt.Fprintf(tw, "%v\t%v\t%v\t", i+1, badge, g.Priority())
+			} else {
+				fmt.Fprintf(tw, "%v\t\t\t", i+1)
+			}
+			shared := string(r.Shared.Items())
+			if !r.Split {
+				shared = "(odd)"
+			}
+			fmt.Fprintf(tw, "%v\t%v\t%v\n", r.Line, r.Items, shared)
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "\ngroups: %v, flagged: %v, total: %v\n", len(groups), len(flagged), total)
+	for _, f := range flagged {
+		fmt.Fprintln(w, f)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"advent2022/input"
+	"advent2022/registry"
+)
+
+// answer is one line of testdata/answers.txt.
+type answer struct {
+	day    int
+	part   int
+	input  string
+	answer string
+}
+
+// inputPath returns the path of the named input in the day's testdata.
+func inputPath(day int, name string) string {
+	return filepath.Join("..", "..", fmt.Sprintf("day%v", day), "testdata", name+".txt")
+}
+
+func readAnswers(t *testing.T) []answer {
+	t.Helper()
+	b, err := os.ReadFile(filepath.Join("testdata", "answers.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var answers []answer
+	for i, line := range strings.Split(string(b), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 4 {
+			t.Fatalf("answers.txt:%v: %q has %v fields, want 4", i+1, line, len(fields))
+		}
+		day, err := strconv.Atoi(fields[0])
+		if err != nil {
+			t.Fatalf("answers.txt:%v: %v", i+1, err)
+		}
+		part, err := strconv.Atoi(fields[1])
+		if err != nil {
+			t.Fatalf("answers.txt:%v: %v", i+1, err)
+		}
+		answers = append(answers, answer{day, part, fields[2], fields[3]})
+	}
+	return answers
+}
+
+func TestGolden(t *testing.T) {
+	for _, a := range readAnswers(t) {
+		a := a
+		t.Run(fmt.Sprintf("day%v/part%v/%v", a.day, a.part, a.input), func(t *testing.T) {
+			s, ok := registry.Lookup(a.day, a.part)
+			if !ok {
+				t.Fatalf("day %v part %v has no solver", a.day, a.part)
+			}
+			in, err := input.ReadFile(inputPath(a.day, a.input))
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := s(in)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != a.answer {
+				t.Errorf("got %q, want %q", got, a.answer)
+			}
+		})
+	}
+}
+
+func TestGoldenCoversEverySolver(t *testing.T) {
+	covered := map[string]bool{}
+	for _, a := range readAnswers(t) {
+		covered[fmt.Sprintf("%v/%v/%v", a.day, a.part, a.input)] = true
+	}
+	for _, day := range registry.Days() {
+		for _, part := range registry.Parts(day) {
+			for _, name := range []string{"sample", "input"} {
+				if !covered[fmt.Sprintf("%v/%v/%v", day, part, name)] {
+					t.Errorf("answers.txt has no %v answer for day %v part %v", name, day, part)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,143 @@
+// Command advent runs the Advent of Code 2022 solvers.
+//
+// Usage:
+//
+//	advent run -day 5 -part 2 -input path
+//	advent bench -baseline bench.json
+//	advent day1 -top 5
+//	advent day1 -stats -format json
+//	advent day1 -stream -input - < inventory.txt
+//	advent day1 -big
+//	advent day1 -workers 8
+//	advent day2 -guide shapes -rules day2/rules/rpsls.json
+//	advent day2 -guide outcomes -trace
+//	advent day2 -optimize -max-wins 100 -max-run 3
+//	advent day2 -simulate -matches 1000 -rounds 100 -seed 1
+//	advent day3 -mode compartments
+//	advent day3 -mode badges -group 4
+//	advent day3 -report
+//
+// Without -input, the input for day N is read from inputs/dayN.txt.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"advent2022/input"
+	"advent2022/registry"
+
+	_ "advent2022/day1"
+	_ "advent2022/day2"
+	_ "advent2022/day3"
+	_ "advent2022/day4"
+	_ "advent2022/day5"
+	_ "advent2022/day6"
+	_ "advent2022/day7"
+)
+
+var commands = map[string]func(args []string) error{
+	"run":   run,
+	"bench": runBench,
+	"day1":  runDay1,
+	"day2":  runDay2,
+	"day3":  runDay3,
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: advent <command> [flags]\n\ncommands:\n")
+	fmt.Fprintf(os.Stderr, "  run\tsolve a day's puzzle\n")
+	fmt.Fprintf(os.Stderr, "  bench\ttime every day's solvers\n")
+	fmt.Fprintf(os.Stderr, "  day1\treport the elves carrying the most calories\n")
+	fmt.Fprintf(os.Stderr, "  day2\tscore a rock paper scissors strategy guide\n")
+	fmt.Fprintf(os.Stderr, "  day3\tsum the priorities of misplaced items or badges\n")
+}
+
+func run(args []string) error {
+	fs := flag.NewFlagSet("run", flag.ExitOnError)
+	day := fs.Int("day", 0, "day to solve; 0 solves every day")
+	part := fs.Int("part", 0, "part to solve; 0 solves every part")
+	path := fs.String("input", "", `path to the puzzle input, or "-" for stdin; defaults to the day's file in -inputs`)
+	fs.StringVar(&input.Dir, "inputs", input.Dir, "directory holding the default inputs")
+	fs.Parse(args)
+	if *path != "" && *day == 0 {
+		return fmt.Errorf("-input needs -day")
+	}
+	days := registry.Days()
+	if *day != 0 {
+		days = []int{*day}
+	}
+	for _, d := range days {
+		parts := registry.Parts(d)
+		if *part != 0 {
+			parts = []int{*part}
+		}
+		if len(parts) == 0 {
+			return fmt.Errorf("day %v has no solvers", d)
+		}
+		in, err := input.Load(d, *path)
+		if err != nil {
+			return err
+		}
+		for _, p := range parts {
+			s, ok := registry.Lookup(d, p)
+			if !ok {
+				return fmt.Errorf("day %v part %v has no solver", d, p)
+			}
+			answer, err := s(in)
+			if err != nil {
+				return fmt.Errorf("day %v part %v: %v", d, p, err)
+			}
+			fmt.Printf("day %v part %v: %v\n", d, p, answer)
+		}
+	}
+	return nil
+}
+
+// isSet reports whether the named flag was given on the command line.
+func isSet(fs *flag.FlagSet, name string) bool {
+	set := false
+	fs.Visit(func(f *flag.Flag) {
+		set = set || f.Name == name
+	})
+	return set
+}
+
+// exclusive returns an error if more than one of the named flags was given on
+// the command line.
+func exclusive(fs *flag.FlagSet, names ...string) error {
+	var set []string
+	for _, name := range names {
+		if isSet(fs, name) {
+			set = append(set, "-"+name)
+		}
+	}
+	if len(set) > 1 {
+		return fmt.Errorf("%v cannot be combined", strings.Join(set, ", "))
+	}
+	return nil
+}
+
+func main() {
+	flag.Usage = usage
+	flag.Parse()
+	if flag.NArg() == 0 {
+		usage()
+		os.Exit(2)
+	}
+	cmd, ok := commands[flag.Arg(0)]
+	if !ok {
+		usage()
+		os.Exit(2)
+	}
+	if err := cmd(flag.Args()[1:]); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func init() {
+	log.SetFlags(log.Flags() | log.Lshortfile)
+}
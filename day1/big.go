@@ -0,0 +1,79 @@
+package day1
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"advent2022/ds/heap"
+)
+
+// BigElf is the food carried by one elf, counted with arbitrary precision.
+type BigElf struct {
+	// Index is the elf's 1-based position in the input.
+	Index    int
+	Calories *big.Int
+}
+
+// parseBig is like parse but counts calories with arbitrary precision.
+func parseBig(input string) ([]*big.Int, error) {
+	var totals []*big.Int
+	calories := new(big.Int)
+	var items int
+	for i, line := range strings.Split(input, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			if items > 0 {
+				totals = append(totals, calories)
+				calories, items = new(big.Int), 0
+			}
+			continue
+		}
+		c, ok := new(big.Int).SetString(line, 10)
+		if !ok || c.Sign() < 0 {
+			return nil, fmt.Errorf("line %v (elf %v): invalid number %q", i+1, len(totals)+1, line)
+		}
+		calories.Add(calories, c)
+		items++
+	}
+	if items > 0 {
+		totals = append(totals, calories)
+	}
+	if len(totals) == 0 {
+		return nil, fmt.Errorf("no elves found")
+	}
+	return totals, nil
+}
+
+// TopBig is like Top but counts calories with arbitrary precision, so the
+// totals are exact however large they grow.
+func TopBig(input string, k int) ([]BigElf, *big.Int, error) {
+	if k < 1 {
+		return nil, nil, fmt.Errorf("k is %v, want at least 1", k)
+	}
+	totals, err := parseBig(input)
+	if err != nil {
+		return nil, nil, err
+	}
+	elves := make([]BigElf, len(totals))
+	for i, calories := range totals {
+		elves[i] = BigElf{Index: i + 1, Calories: calories}
+	}
+	h := heap.Heapify(elves, func(a, b BigElf) bool {
+		if c := a.Calories.Cmp(b.Calories); c != 0 {
+			return c > 0
+		}
+		return a.Index < b.Index
+	})
+	var best []BigElf
+	sum := new(big.Int)
+	for len(best) < k {
+		elf, ok := h.Pop()
+		if !ok {
+			break
+		}
+		best = append(best, elf)
+		sum.Add(sum, elf.Calories)
+	}
+	return best, sum, nil
+}
@@ -0,0 +1,297 @@
+package day1
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"math/rand"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+
+	"advent2022/bench"
+	"advent2022/input"
+	"advent2022/registry"
+)
+
+func TestTop(t *testing.T) {
+	in, err := input.ReadFile("testdata/sample.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		k     int
+		elves []Elf
+		sum   int
+	}{
+		{1, []Elf{{4, 24000}}, 24000},
+		{3, []Elf{{4, 24000}, {3, 11000}, {5, 10000}}, 45000},
+		{10, []Elf{{4, 24000}, {3, 11000}, {5, 10000}, {1, 6000}, {2, 4000}}, 55000},
+	}
+	for _, tt := range tests {
+		elves, sum, err := Top(in, tt.k)
+		if err != nil {
+			t.Fatalf("Top(%v): %v", tt.k, err)
+		}
+		if !reflect.DeepEqual(elves, tt.elves) || sum != tt.sum {
+			t.Errorf("Top(%v) = %v, %v, want %v, %v", tt.k, elves, sum, tt.elves, tt.sum)
+		}
+	}
+	if _, _, err := Top(in, 0); err == nil {
+		t.Errorf("Top(0) succeeded, want an error")
+	}
+}
+
+func TestTopReader(t *testing.T) {
+	for _, name := range []string{"testdata/sample.txt", "testdata/input.txt"} {
+		in, err := input.ReadFile(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, k := range []int{1, 3, 7, 1000} {
+			wantElves, wantSum, err := Top(in, k)
+			if err != nil {
+				t.Fatal(err)
+			}
+			elves, sum, err := TopReader(strings.NewReader(in+"\n"), k)
+			if err != nil {
+				t.Fatalf("%v: TopReader(%v): %v", name, k, err)
+			}
+			if !reflect.DeepEqual(elves, wantElves) || sum != wantSum {
+				t.Errorf("%v: TopReader(%v) = %v, %v, want %v, %v", name, k, elves, sum, wantElves, wantSum)
+			}
+		}
+	}
+}
+
+func TestParseTolerance(t *testing.T) {
+	tests := []string{
+		"1000\n2000\n\n3000\n",
+		"1000\r\n2000\r\n\r\n3000\r\n\r\n",
+		"\n 1000 \n2000\t\n\n\n\n  3000\n\n\n",
+	}
+	for _, in := range tests {
+		totals, counts, err := parse(in)
+		if err != nil {
+			t.Errorf("parse(%q): %v", in, err)
+			continue
+		}
+		if want := []int{3000, 3000}; !reflect.DeepEqual(totals, want) {
+			t.Errorf("parse(%q) totals = %v, want %v", in, totals, want)
+		}
+		if want := []int{2, 1}; !reflect.DeepEqual(counts, want) {
+			t.Errorf("parse(%q) counts = %v, want %v", in, counts, want)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"1000\n\n2000\n12a\n", `line 4 (elf 2): invalid number "12a"`},
+		{"1000\r\n\r\n\r\n-5\r\n", `line 4 (elf 2): invalid number "-5"`},
+		{"\n\n", "no elves found"},
+	}
+	for _, tt := range tests {
+		if _, _, err := parse(tt.in); err == nil || err.Error() != tt.want {
+			t.Errorf("parse(%q) error = %v, want %v", tt.in, err, tt.want)
+		}
+		if _, _, err := TopReader(strings.NewReader(tt.in), 3); err == nil || err.Error() != tt.want {
+			t.Errorf("TopReader(%q) error = %v, want %v", tt.in, err, tt.want)
+		}
+	}
+}
+
+func TestOverflow(t *testing.T) {
+	in := fmt.Sprintf("%v\n%v\n\n1\n", math.MaxInt, 1)
+	_, _, err := Top(in, 1)
+	if !errors.Is(err, ErrOverflow) {
+		t.Fatalf("Top() error = %v, want %v", err, ErrOverflow)
+	}
+	if want := "line 2 (elf 1): " + ErrOverflow.Error(); err.Error() != want {
+		t.Errorf("Top() error = %q, want %q", err, want)
+	}
+	if _, _, err := TopReader(strings.NewReader(in), 1); !errors.Is(err, ErrOverflow) {
+		t.Errorf("TopReader() error = %v, want %v", err, ErrOverflow)
+	}
+	in = fmt.Sprintf("%v\n\n%v\n", math.MaxInt, math.MaxInt)
+	if _, _, err := Top(in, 2); !errors.Is(err, ErrOverflow) {
+		t.Errorf("Top() of two huge elves error = %v, want %v", err, ErrOverflow)
+	}
+}
+
+func TestTopBig(t *testing.T) {
+	in := fmt.Sprintf("%v\n%v\n\n1\n\n123456789012345678901234567890\n", math.MaxInt, math.MaxInt)
+	elves, sum, err := TopBig(in, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	huge, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	twice := new(big.Int).Mul(big.NewInt(math.MaxInt), big.NewInt(2))
+	if len(elves) != 2 || elves[0].Index != 3 || elves[0].Calories.Cmp(huge) != 0 ||
+		elves[1].Index != 1 || elves[1].Calories.Cmp(twice) != 0 {
+		t.Errorf("TopBig() elves = %v, want elf 3 with %v then elf 1 with %v", elves, huge, twice)
+	}
+	if want := new(big.Int).Add(huge, twice); sum.Cmp(want) != 0 {
+		t.Errorf("TopBig() sum = %v, want %v", sum, want)
+	}
+	sample, err := input.ReadFile("testdata/sample.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, sum, err := TopBig(sample, 3); err != nil || sum.Int64() != 45000 {
+		t.Errorf("TopBig(sample) sum = %v, %v, want 45000", sum, err)
+	}
+}
+
+// inventory returns a random inventory of n elves, separated by blank lines
+// that sometimes carry stray whitespace.
+func inventory(r *rand.Rand, n int) string {
+	var b strings.Builder
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			b.WriteString([]string{"\n", "\n\n", " \r\n"}[r.Intn(3)])
+		}
+		for j := r.Intn(5); j >= 0; j-- {
+			fmt.Fprintf(&b, "%v\n", r.Intn(1000))
+		}
+	}
+	return b.String()
+}
+
+func TestTopParallel(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	real, err := input.ReadFile("testdata/input.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ins := []string{real, "1000", "1\n\n2\n"}
+	for i := 0; i < 50; i++ {
+		ins = append(ins, inventory(r, 1+r.Intn(200)))
+	}
+	for _, in := range ins {
+		for _, workers := range []int{1, 2, 3, 8, 500} {
+			for _, k := range []int{1, 3, 10} {
+				wantElves, wantSum, err := Top(in, k)
+				if err != nil {
+					t.Fatal(err)
+				}
+				elves, sum, err := TopParallel(in, k, workers)
+				if err != nil {
+					t.Fatalf("TopParallel(%v, %v): %v", k, workers, err)
+				}
+				if !reflect.DeepEqual(elves, wantElves) || sum != wantSum {
+					t.Fatalf("TopParallel(%v, %v) = %v, %v, want %v, %v", k, workers, elves, sum, wantElves, wantSum)
+				}
+			}
+		}
+	}
+}
+
+func TestTopParallelErrors(t *testing.T) {
+	in := strings.Repeat("1000\n2000\n\n", 100) + "12a\n" + strings.Repeat("\n3000\n", 100)
+	_, _, want := Top(in, 3)
+	for _, workers := range []int{1, 2, 7} {
+		_, _, err := TopParallel(in, 3, workers)
+		if err == nil || err.Error() != want.Error() {
+			t.Errorf("TopParallel(%v workers) error = %v, want %v", workers, err, want)
+		}
+	}
+}
+
+func TestStatistics(t *testing.T) {
+	in, err := input.ReadFile("testdata/sample.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := Statistics(in, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Stats{
+		Elves:      5,
+		Items:      10,
+		Min:        4000,
+		Max:        24000,
+		Mean:       11000,
+		Median:     10000,
+		P90:        24000,
+		P99:        24000,
+		ItemCounts: []int{3, 1, 2, 3, 1},
+		Histogram:  []Bin{{4000, 14000, 4}, {14001, 24001, 1}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Statistics() = %+v, want %+v", got, want)
+	}
+}
+
+func TestStatisticsNearMaxInt(t *testing.T) {
+	got, err := Statistics(fmt.Sprintf("%v\n\n%v\n", math.MaxInt, math.MaxInt-1), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := float64(math.MaxInt - 1); got.Median != want {
+		t.Errorf("Median = %v, want %v", got.Median, want)
+	}
+	if want := []Bin{{math.MaxInt - 1, math.MaxInt, 2}}; !reflect.DeepEqual(got.Histogram, want) {
+		t.Errorf("Histogram = %+v, want %+v", got.Histogram, want)
+	}
+
+	for _, bins := range []int{1, 2, 10} {
+		got, err := Statistics(fmt.Sprintf("0\n\n%v\n", math.MaxInt), bins)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Median != float64(math.MaxInt)/2 {
+			t.Errorf("Median = %v, want %v", got.Median, float64(math.MaxInt)/2)
+		}
+		h := got.Histogram
+		if len(h) != bins || h[0].Low != 0 || h[bins-1].High != math.MaxInt {
+			t.Errorf("Statistics(%v bins) histogram = %+v", bins, h)
+		}
+		var count int
+		for _, b := range h {
+			count += b.Count
+		}
+		if count != 2 {
+			t.Errorf("Statistics(%v bins) histogram counts %v elves, want 2", bins, count)
+		}
+		for i := 1; i < bins; i++ {
+			if h[i].Low != h[i-1].High+1 || h[i].Low <= h[i-1].Low {
+				t.Errorf("Statistics(%v bins) bins %v and %v are not contiguous: %+v", bins, i-1, i, h)
+			}
+		}
+	}
+}
+
+func BenchmarkTopParallel(b *testing.B) {
+	in := inventory(rand.New(rand.NewSource(1)), 100000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, _, err := TopParallel(in, 3, runtime.GOMAXPROCS(0)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkTop(b *testing.B) {
+	in := inventory(rand.New(rand.NewSource(1)), 100000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, _, err := Top(in, 3); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkPart1(b *testing.B) {
+	bench.File(b, registry.Int(Part1), "testdata/input.txt")
+}
+
+func BenchmarkPart2(b *testing.B) {
+	bench.File(b, registry.Int(Part2), "testdata/input.txt")
+}
@@ -0,0 +1,105 @@
+package day1
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// TopParallel is like Top but splits the input at elf boundaries into one
+// chunk per worker and sums the chunks concurrently. Each worker keeps its own
+// k best elves, which are then merged into the answer.
+func TopParallel(input string, k, workers int) ([]Elf, int, error) {
+	if k < 1 {
+		return nil, 0, fmt.Errorf("k is %v, want at least 1", k)
+	}
+	if workers < 1 {
+		return nil, 0, fmt.Errorf("workers is %v, want at least 1", workers)
+	}
+	chunks := split(input, workers)
+	type result struct {
+		best  []Elf
+		elves int
+		err   error
+	}
+	results := make([]result, len(chunks))
+	var wg sync.WaitGroup
+	for i, chunk := range chunks {
+		wg.Add(1)
+		go func(i int, chunk string) {
+			defer wg.Done()
+			totals, _, err := parseElves(chunk)
+			if err != nil {
+				results[i].err = err
+				return
+			}
+			results[i] = result{best: top(totals, k), elves: len(totals)}
+		}(i, chunk)
+	}
+	wg.Wait()
+	var candidates []Elf
+	var offset int
+	for _, r := range results {
+		if r.err != nil {
+			// The workers only know where an error is within their own
+			// chunk, so parse again to report it with its place in the
+			// whole input.
+			if _, _, err := parse(input); err != nil {
+				return nil, 0, err
+			}
+			return nil, 0, r.err
+		}
+		for _, elf := range r.best {
+			elf.Index += offset
+			candidates = append(candidates, elf)
+		}
+		offset += r.elves
+	}
+	if offset == 0 {
+		return nil, 0, fmt.Errorf("no elves found")
+	}
+	elves := best(candidates, k)
+	total, err := sum(elves)
+	if err != nil {
+		return nil, 0, err
+	}
+	return elves, total, nil
+}
+
+// split cuts input into at most n chunks of about the same size. Each cut is
+// made just after a blank line so that no elf is split across chunks.
+func split(input string, n int) []string {
+	var chunks []string
+	for n > 1 && len(input) > 0 {
+		i := cut(input, len(input)/n)
+		if i == len(input) {
+			break
+		}
+		chunks = append(chunks, input[:i])
+		input = input[i:]
+		n--
+	}
+	return append(chunks, input)
+}
+
+// cut returns the index just after the first blank line that starts at or
+// after index i of input, or len(input) if there is none.
+func cut(input string, i int) int {
+	// Move to the start of the next line.
+	if j := strings.IndexByte(input[i:], '\n'); j >= 0 {
+		i += j + 1
+	} else {
+		return len(input)
+	}
+	for i < len(input) {
+		end := len(input)
+		if j := strings.IndexByte(input[i:], '\n'); j >= 0 {
+			end = i + j + 1
+		}
+		if strings.TrimSpace(input[i:end]) == "" {
+			return end
+		}
+		i = end
+	}
+	return len(input)
+}
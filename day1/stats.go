@@ -0,0 +1,108 @@
+package day1
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Stats summarizes the calories carried by the elves.
+type Stats struct {
+	Elves  int     `json:"elves"`
+	Items  int     `json:"items"`
+	Min    int     `json:"min"`
+	Max    int     `json:"max"`
+	Mean   float64 `json:"mean"`
+	Median float64 `json:"median"`
+	P90    int     `json:"p90"`
+	P99    int     `json:"p99"`
+	// ItemCounts holds the number of items each elf carries, in input order.
+	ItemCounts []int `json:"item_counts"`
+	Histogram  []Bin `json:"histogram"`
+}
+
+// Bin counts the elves whose total calories fall in [Low, High].
+type Bin struct {
+	Low   int `json:"low"`
+	High  int `json:"high"`
+	Count int `json:"count"`
+}
+
+// Statistics summarizes the calories carried by the elves, grouping the
+// per-elf totals into the given number of equally wide histogram bins.
+func Statistics(input string, bins int) (Stats, error) {
+	if bins < 1 {
+		return Stats{}, fmt.Errorf("bins is %v, want at least 1", bins)
+	}
+	totals, counts, err := parse(input)
+	if err != nil {
+		return Stats{}, err
+	}
+	sorted := append([]int(nil), totals...)
+	sort.Ints(sorted)
+	s := Stats{
+		Elves:      len(sorted),
+		Min:        sorted[0],
+		Max:        sorted[len(sorted)-1],
+		Median:     median(sorted),
+		P90:        percentile(sorted, 90),
+		P99:        percentile(sorted, 99),
+		ItemCounts: counts,
+		Histogram:  histogram(sorted, bins),
+	}
+	// Sum as floats so that the mean of totals near the limit of an int
+	// cannot overflow.
+	var sum float64
+	for _, calories := range sorted {
+		sum += float64(calories)
+	}
+	s.Mean = sum / float64(len(sorted))
+	for _, n := range counts {
+		s.Items += n
+	}
+	return s, nil
+}
+
+func median(sorted []int) float64 {
+	n := len(sorted)
+	if n%2 == 1 {
+		return float64(sorted[n/2])
+	}
+	a, b := sorted[n/2-1], sorted[n/2]
+	return float64(a) + float64(b-a)/2
+}
+
+// percentile returns the nearest-rank p-th percentile of sorted.
+func percentile(sorted []int, p int) int {
+	rank := int(math.Ceil(float64(p) / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// histogram groups the non-negative totals in sorted into equally wide bins.
+// The width is computed as a uint64 so that totals near the limit of an int
+// cannot overflow it, and bin bounds saturate at math.MaxInt.
+func histogram(sorted []int, bins int) []Bin {
+	lo, hi := sorted[0], sorted[len(sorted)-1]
+	width := uint64(hi-lo)/uint64(bins) + 1
+	h := make([]Bin, bins)
+	for i := range h {
+		low := bound(lo, i, width)
+		h[i] = Bin{Low: low, High: bound(low, 1, width-1)}
+	}
+	for _, calories := range sorted {
+		h[uint64(calories-lo)/width].Count++
+	}
+	return h
+}
+
+// bound returns lo + i*width for non-negative lo, or math.MaxInt if that does
+// not fit in an int.
+func bound(lo, i int, width uint64) int {
+	if i > 0 && width > (math.MaxInt-uint64(lo))/uint64(i) {
+		return math.MaxInt
+	}
+	return lo + i*int(width)
+}
@@ -0,0 +1,76 @@
+package day1
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"advent2022/ds/heap"
+)
+
+// TopReader is like Top but reads the inventory from r one line at a time.
+// Only the k best elves seen so far are kept in memory, so inventories of any
+// size can be processed.
+func TopReader(r io.Reader, k int) ([]Elf, int, error) {
+	if k < 1 {
+		return nil, 0, fmt.Errorf("k is %v, want at least 1", k)
+	}
+	// The root of the heap is the worst of the best elves, which is the one
+	// to evict when a better elf comes along.
+	h := heap.New(func(a, b Elf) bool {
+		return better(b, a)
+	})
+	var (
+		index    int
+		calories int
+		items    int
+		line     int
+	)
+	flush := func() {
+		if items == 0 {
+			return
+		}
+		index++
+		h.Push(Elf{Index: index, Calories: calories})
+		if h.Len() > k {
+			h.Pop()
+		}
+		calories, items = 0, 0
+	}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			flush()
+			continue
+		}
+		c, err := parseCalories(text, line, index+1)
+		if err != nil {
+			return nil, 0, err
+		}
+		calories, err = add(calories, c)
+		if err != nil {
+			return nil, 0, fmt.Errorf("line %v (elf %v): %w", line, index+1, err)
+		}
+		items++
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, 0, fmt.Errorf("read inventory: %v", err)
+	}
+	flush()
+	if index == 0 {
+		return nil, 0, fmt.Errorf("no elves found")
+	}
+	// Drain returns the worst elf first.
+	elves := h.Drain()
+	for i, j := 0, len(elves)-1; i < j; i, j = i+1, j-1 {
+		elves[i], elves[j] = elves[j], elves[i]
+	}
+	total, err := sum(elves)
+	if err != nil {
+		return nil, 0, err
+	}
+	return elves, total, nil
+}
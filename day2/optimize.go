@@ -0,0 +1,142 @@
+package day2
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Unlimited disables a limit in Constraints.
+const Unlimited = -1
+
+// Constraints limit the guides that Optimize may produce.
+type Constraints struct {
+	// MaxWins is the most rounds the guide may win, or Unlimited.
+	MaxWins int
+	// MaxRun is the most rounds in a row the guide may play the same
+	// shape, or Unlimited.
+	MaxRun int
+}
+
+// Optimize returns the highest score that can be had against the opponent's
+// moves in g while keeping to c, along with the strategy guide that gets it.
+// The second column of g is ignored. The returned guide's second column names
+// the shapes to play, so it can be scored with the Shapes interpretation.
+func (r *Rules) Optimize(g Guide, c Constraints) (int, string, error) {
+	if c.MaxWins < Unlimited || c.MaxRun < Unlimited || c.MaxRun == 0 {
+		return 0, "", fmt.Errorf("constraints %+v are invalid", c)
+	}
+	var opponents []Shape
+	for _, rd := range g {
+		s, ok := r.move(rd)
+		if !ok {
+			return 0, "", fmt.Errorf("line %v: move %q unrecognized", rd.Line, rd.Opponent)
+		}
+		opponents = append(opponents, s)
+	}
+	n, shapes := len(opponents), len(r.Shapes)
+	// A state is the number of wins so far, the last shape played and how
+	// many times in a row it was played. Limits that cannot bind are left
+	// out of the state to keep it small.
+	limitWins := c.MaxWins != Unlimited && c.MaxWins < n
+	limitRuns := c.MaxRun != Unlimited && c.MaxRun < n
+	wins, runs := 1, 1
+	if limitWins {
+		wins = c.MaxWins + 1
+	}
+	if limitRuns {
+		runs = c.MaxRun
+	}
+	states := wins * shapes * runs
+	index := func(w, s, run int) int {
+		return (w*shapes+s)*runs + run - 1
+	}
+	const unreachable = math.MinInt
+	best := make([]int, states)
+	next := make([]int, states)
+	// from[i][state] is the state before round i that led to state.
+	from := make([][]int32, n)
+	for i := range best {
+		best[i] = unreachable
+	}
+	for i, opponent := range opponents {
+		for j := range next {
+			next[j] = unreachable
+		}
+		from[i] = make([]int32, states)
+		relax := func(prev, total, w, s, run int, p Play) {
+			if p.Outcome == "win" && limitWins {
+				w++
+				if w >= wins {
+					return
+				}
+			}
+			if run > runs {
+				if limitRuns {
+					return
+				}
+				run = 1
+			}
+			k := index(w, s, run)
+			if total += p.Score(); total > next[k] {
+				next[k] = total
+				from[i][k] = int32(prev)
+			}
+		}
+		for s, shape := range r.Shapes {
+			p := r.play(opponent, shape)
+			if i == 0 {
+				relax(-1, 0, 0, s, 1, p)
+				continue
+			}
+			for w := 0; w < wins; w++ {
+				for last := 0; last < shapes; last++ {
+					for run := 1; run <= runs; run++ {
+						prev := index(w, last, run)
+						if best[prev] == unreachable {
+							continue
+						}
+						if last == s {
+							relax(prev, best[prev], w, s, run+1, p)
+						} else {
+							relax(prev, best[prev], w, s, 1, p)
+						}
+					}
+				}
+			}
+		}
+		best, next = next, best
+	}
+	end, total := -1, unreachable
+	for k, v := range best {
+		if v > total {
+			end, total = k, v
+		}
+	}
+	if end < 0 {
+		return 0, "", fmt.Errorf("no guide keeps to %+v", c)
+	}
+	played := make([]int, n)
+	for i := n - 1; i >= 0; i-- {
+		played[i] = (end / runs) % shapes
+		end = int(from[i][end])
+	}
+	var b strings.Builder
+	for i, opponent := range opponents {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		fmt.Fprintf(&b, "%v %v", opponent.Opponent, r.Shapes[played[i]].Player)
+	}
+	return total, b.String(), nil
+}
+
+// Optimize is Rules.Optimize under the Default rules. Since only the
+// opponent's moves matter, input is read with ParseOpponents.
+func Optimize(input string, c Constraints) (int, string, error) {
+	g, err := ParseOpponents(input)
+	if err != nil {
+		return 0, "", err
+	}
+	return Default.Optimize(g, c)
+}
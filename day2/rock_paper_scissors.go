@@ -1,2569 +1,144 @@
-package main
+package day2
 
 import (
 	"fmt"
-	"log"
 	"strings"
+
+	"advent2022/registry"
 )
 
-const input = `C Z
-A Y
-C Z
-A Y
-C Y
-A Z
-B Y
-C X
-A Y
-B X
-B X
-A Y
-C Z
-C Y
-C Y
-A Y
-C Y
-B X
-B X
-A Z
-C Y
-B Z
-C Z
-A Z
-B Y
-A Y
-C Z
-A Z
-A Y
-C Z
-C Z
-A X
-A Y
-B Y
-C X
-B Y
-C Z
-A Z
-A X
-A Z
-A Y
-C Y
-C X
-C Y
-A Z
-C Z
-C Z
-A Y
-A Z
-C Z
-A Y
-C Z
-C Y
-C Z
-A Z
-A Y
-C Z
-C Y
-C Y
-C X
-B Y
-B Y
-C Y
-A Z
-A Z
-B X
-B Z
-B X
-A Y
-A Z
-A Y
-C Y
-B X
-B Y
-A Z
-C Y
-C Z
-A Y
-B X
-C Z
-A Y
-C Z
-C Z
-A Y
-B Z
-C Z
-A Y
-B X
-A Y
-C Z
-C Z
-B Y
-B Z
-B Z
-A Y
-A Z
-B Y
-C Z
-C Y
-C Z
-A Y
-C Z
-C Z
-C Z
-C Y
-A Z
-A Z
-C Z
-C Z
-A Z
-C Z
-C Y
-C X
-A Z
-A Z
-B Z
-A Y
-A Y
-C X
-B Y
-C Y
-A Y
-B X
-B Y
-B Y
-B Y
-C Y
-A Z
-C Y
-A Y
-C Z
-B Y
-B X
-A Z
-C Y
-B Y
-A Y
-C Z
-B Z
-B Z
-A X
-B Y
-C Z
-B Z
-B Y
-C Z
-B X
-B Y
-A Y
-A Y
-A Y
-B Y
-B Y
-A Z
-C Z
-A Y
-A Z
-A Y
-A X
-C Y
-A Y
-C Z
-A Z
-C Z
-C Y
-C X
-B Z
-A Z
-C Y
-C Z
-A Z
-C Z
-C Z
-C Y
-C Z
-C Y
-C Z
-C Y
-C Z
-C Z
-C Y
-B X
-A Y
-C Y
-C Z
-B Y
-B Y
-C X
-C Z
-A Z
-B X
-C Z
-A Y
-B Y
-C Z
-C Z
-C Z
-B X
-A Y
-C Z
-B Z
-B X
-C Z
-C Z
-C X
-A Y
-A Y
-C Z
-A Y
-A Y
-A Y
-B X
-C Z
-B X
-C Z
-A Y
-A Z
-B X
-B Z
-C Z
-A Y
-C Y
-C Z
-C Y
-B X
-B Y
-C X
-C Z
-A Y
-A Y
-B Z
-A Y
-C Z
-C Z
-B Y
-B X
-C Z
-C Y
-C Y
-B Y
-C Y
-A Y
-C X
-C Z
-A Y
-A Y
-C Y
-A Y
-C X
-C Z
-A Y
-B Z
-A Y
-A Y
-A Y
-C Z
-B X
-B Y
-C Y
-A Z
-C Y
-C X
-B Z
-A Y
-C Z
-A Y
-B Y
-B X
-A Y
-C X
-C X
-B Y
-C Z
-B X
-C X
-C Z
-A Y
-C Z
-C X
-C Z
-B Y
-C Z
-C Z
-A Y
-C Z
-B Y
-A Y
-A Y
-C Y
-C Z
-B X
-C Z
-C X
-C Y
-B Z
-A Y
-B X
-C Y
-B Y
-C Y
-B Y
-A Y
-A Y
-B X
-B Z
-B X
-B Y
-C X
-C Z
-A Y
-C Z
-C Z
-C Z
-C Z
-B Z
-C Z
-B Y
-A Y
-C Y
-C X
-C Z
-B X
-C Z
-B Y
-C X
-C Z
-A Y
-A Z
-B Z
-A Y
-B Y
-B Z
-C Y
-B X
-A X
-C Y
-C X
-B Y
-C X
-C Z
-C Z
-C Z
-B X
-C X
-A X
-B Y
-B Z
-C Z
-C Z
-C Y
-C Z
-B Y
-C Y
-C Y
-C Z
-B X
-B Z
-B Y
-B Y
-C Z
-B Y
-A X
-B X
-A Z
-C Z
-C Z
-A Y
-C Z
-A Y
-C Y
-B Z
-B Z
-C Z
-C X
-A Y
-C X
-B Z
-A Y
-B X
-A Z
-A Z
-B X
-C X
-C Y
-B Y
-A Y
-B Y
-C Z
-C Z
-C Y
-A Y
-C Y
-C Z
-C Y
-C Z
-C Z
-C Z
-A Y
-A Z
-A Y
-C Z
-B X
-A Y
-C Z
-C Y
-C X
-C Y
-C Z
-C Y
-A Y
-A Z
-C Y
-A Z
-C Y
-A Y
-A Y
-C Z
-C Y
-A Y
-A Y
-B Y
-B Z
-A Z
-C Z
-C Z
-C Z
-C X
-B X
-C Z
-C Y
-A Z
-B Y
-C Z
-C Z
-B Y
-A Z
-C Z
-B Z
-B Y
-C Y
-C Y
-A Z
-C Z
-A Z
-B Y
-C Y
-B X
-C X
-B Y
-A Z
-C Z
-C Y
-C Z
-B X
-C Z
-C Y
-C X
-C Z
-A Y
-A Z
-C Y
-B Y
-A Z
-B X
-C Z
-C Y
-C Y
-C Z
-A Z
-A Z
-B Y
-B X
-B X
-A Y
-B X
-C Y
-A Y
-A Y
-B X
-C Y
-B Z
-A Z
-B X
-C Y
-C X
-A Y
-A Z
-A Y
-C Z
-B Z
-C Z
-A Y
-C Z
-C X
-A Z
-B Y
-A Y
-B Y
-C Z
-C X
-A Z
-C Y
-B X
-A Y
-C X
-C Y
-A Z
-B Z
-A Y
-C Z
-B Y
-A Y
-C Y
-C X
-B X
-C Z
-B X
-C Y
-C X
-C Y
-C Y
-B X
-A Y
-B X
-C Y
-C Z
-B X
-C Z
-B Y
-A Y
-C Z
-A Z
-A X
-B Y
-A Z
-A Y
-C Z
-C X
-B Y
-C Y
-A Y
-C Y
-A Z
-A Z
-A Z
-C Y
-A Y
-B Y
-C X
-C X
-C Z
-A Z
-C Y
-C Z
-C Y
-C Y
-A X
-B Z
-B Y
-A Y
-A X
-A Z
-C Z
-A X
-A Y
-C Z
-C Z
-C Y
-C Z
-C Z
-C X
-B Y
-B Z
-B Y
-A Y
-B Y
-B X
-A Z
-B Y
-C Y
-A Z
-B Y
-B Y
-C X
-C Z
-B X
-A Y
-C Z
-C X
-A Z
-A Y
-A Z
-C Z
-B Y
-B Y
-C Z
-A Y
-A Y
-C Y
-B Y
-C Y
-C Y
-C Y
-A Y
-C Z
-B X
-A Y
-B Z
-A Y
-B X
-C Z
-C Y
-C Y
-A Y
-A X
-C Z
-B X
-B X
-B X
-C Y
-C Y
-A Z
-A Y
-C Z
-B Z
-C Z
-C Z
-C Y
-A Y
-C Y
-A Z
-A Y
-B Y
-C Z
-C Y
-B Y
-A X
-B Y
-A Z
-B Y
-A X
-A Y
-B X
-A Y
-B Y
-B X
-A X
-A Z
-C Z
-A Z
-A Z
-C Y
-A X
-A Y
-C Z
-C Y
-A Y
-B Y
-A Z
-A Y
-B Y
-C Z
-C Z
-B X
-B Y
-C Y
-A Y
-B Y
-A Y
-B Y
-B Y
-B Y
-C Y
-C Z
-C Z
-B X
-A Y
-A Y
-A Y
-C Y
-C Y
-A Y
-A Y
-C Z
-C Z
-B Y
-B X
-A Y
-C Z
-A Y
-B Z
-B Y
-C Y
-C Y
-C Y
-B X
-A Y
-C Z
-A Z
-B X
-C Z
-B Y
-A Z
-C Y
-C Y
-A Y
-B X
-A Y
-A Y
-B X
-C Y
-C Y
-A Z
-C Z
-C X
-C Y
-B Y
-B Y
-C Y
-C X
-A Y
-B Z
-A Y
-B Z
-A Z
-A Y
-C Z
-C Z
-C Z
-C Z
-C Z
-C Z
-C Y
-A Y
-C Z
-A Z
-B Z
-C Z
-A Z
-A Y
-C X
-A Z
-A Y
-C Z
-B Z
-A Y
-C Y
-B X
-C Z
-C Z
-A Y
-C Y
-B Y
-C Z
-C Y
-C X
-A Z
-B Z
-A Z
-A Y
-B Z
-C Z
-C Z
-A Y
-A Y
-C Z
-A Y
-A X
-C Y
-B Y
-B X
-B Y
-B X
-C X
-C X
-A X
-A Y
-C Y
-A X
-B X
-C Y
-A Z
-B X
-C Z
-C Z
-B Y
-A Y
-C Z
-C Z
-C Y
-A Z
-B X
-C Y
-A Y
-C Z
-C Z
-A Z
-B Z
-B Z
-C Z
-C Y
-C Z
-A Y
-C Z
-C Z
-B X
-C Z
-C Z
-B Z
-C Z
-B Y
-C X
-C Y
-C Z
-B Y
-A Z
-C Z
-A Y
-B Y
-C Z
-C Z
-C Z
-A Y
-A Y
-A X
-C Z
-B X
-C Z
-C Y
-C Y
-A Z
-B X
-C Z
-C Z
-B X
-C Y
-A Z
-C X
-C Y
-B Y
-C Y
-C X
-A Z
-B Z
-A Z
-B X
-C Y
-B X
-C Z
-C Z
-B X
-B Y
-C Z
-B Y
-A Z
-A Y
-B Z
-C X
-C Z
-C Y
-C Z
-B Y
-A Z
-C Z
-B Y
-B Y
-C X
-C Y
-A X
-B Z
-C X
-A Z
-B Z
-A Y
-A Y
-B X
-C Z
-C Z
-A Y
-C Z
-A Z
-C Y
-C Z
-C Z
-A Z
-C Z
-C X
-A Y
-C Y
-C X
-C X
-B X
-C X
-A Y
-B Y
-C Y
-C Z
-C X
-C Z
-A Z
-C Z
-A Y
-A Y
-B Z
-B X
-C X
-A Y
-C Z
-A Y
-C Y
-C X
-B Y
-C Z
-B X
-A Z
-A Z
-C X
-B X
-C Z
-C Z
-B X
-A Y
-A Z
-C Z
-C Z
-C Z
-A Y
-C Z
-B Z
-A Y
-B Z
-A Y
-B Y
-C Y
-A Y
-B Y
-A Z
-A Z
-C Y
-A Z
-C Z
-B X
-C Y
-B X
-B X
-B Y
-B Z
-A Y
-C Y
-C Z
-C Y
-B Z
-C Y
-C Y
-C Z
-A Y
-B Z
-C Y
-C Z
-C Z
-B Z
-A X
-C Z
-B Y
-C Y
-A Z
-C Z
-A X
-C X
-B Y
-A Z
-A Z
-B X
-C Z
-B X
-C Y
-C X
-C Z
-C X
-C Z
-C Z
-B X
-B Y
-A Y
-B X
-C X
-B Y
-A Z
-A Z
-C Y
-C Y
-B X
-C Z
-B Y
-B X
-A Y
-A X
-C Y
-A X
-C Y
-C Z
-C Y
-C X
-C Z
-B X
-B Z
-B Z
-C Z
-B Y
-C Z
-C Y
-A Y
-C Y
-A Y
-C Z
-A Y
-A Y
-A Z
-C X
-C Z
-C Z
-C Z
-B Y
-C Z
-B X
-A Y
-A Z
-C Z
-B X
-A Z
-C Z
-A Z
-A Y
-B Y
-A Z
-C Z
-B Y
-C Y
-C Z
-A X
-C Z
-C Z
-C X
-C Z
-C Z
-C Z
-B Y
-C Y
-B Y
-B Y
-C Z
-B X
-A Z
-A Y
-C Z
-C Y
-B X
-C X
-A Y
-C Z
-A Y
-B Y
-C Z
-C Y
-B Y
-C X
-B X
-B X
-B X
-C Y
-C Y
-C Y
-C X
-C X
-A Y
-B X
-C Z
-A Z
-A X
-B Y
-C Z
-C Y
-A Y
-C Y
-C Y
-C Y
-C Y
-B Y
-B Y
-C Y
-B X
-A Y
-A Z
-A Y
-B Y
-A Y
-C Z
-C Y
-A Y
-B Y
-B Y
-B Y
-C Y
-C Z
-B X
-B Y
-C Z
-C Y
-C Z
-C Z
-C Z
-B Y
-C X
-C Y
-C Z
-C Z
-B Y
-A Y
-B Y
-C Z
-A Y
-A Z
-B X
-B X
-C Z
-B X
-A Y
-C Z
-B Y
-B Y
-C Z
-B X
-A Y
-A Y
-B X
-C Z
-A Z
-C Z
-C Y
-B X
-B Y
-A Y
-C Z
-C Y
-C Z
-C Z
-B X
-B Z
-C Z
-C X
-A Y
-C X
-B Z
-C Z
-C Z
-A X
-C Z
-A Y
-C Y
-A Y
-A Z
-C Y
-B Z
-A Z
-C Z
-C Z
-C Z
-A Y
-C X
-C X
-B Y
-C Y
-A Z
-A Z
-B X
-B Z
-C X
-C X
-C Y
-C Y
-C Z
-A Y
-C Y
-B Z
-B X
-C Y
-C Z
-B Z
-A Y
-A Y
-C Z
-B Y
-C Y
-B Y
-C X
-C X
-C Z
-B Y
-A Y
-C Z
-B Z
-A Y
-A Y
-A Y
-A Z
-B X
-C Z
-A Z
-C Y
-C Z
-B Z
-B Z
-B Y
-C Y
-C Z
-C X
-B Y
-B X
-A Y
-C Y
-A Z
-C Z
-C Z
-A Y
-A Z
-C X
-C Z
-A Z
-C Z
-B X
-C Y
-A Y
-B Z
-B Z
-B Y
-C Z
-C Z
-B X
-A Z
-C Z
-C Y
-B Y
-C Y
-C Z
-C Y
-B X
-B X
-C Z
-C Y
-B Y
-B X
-C Z
-B X
-C Y
-C Z
-C Y
-B Z
-C Z
-A Y
-A Y
-B X
-C Z
-B X
-B X
-A Y
-B X
-B X
-C Y
-C X
-B X
-C Z
-C Z
-C Z
-C Z
-C Y
-B Y
-C Z
-C Z
-A Y
-A Z
-C X
-C Y
-A Z
-C Z
-B X
-B Y
-C Z
-A Z
-C X
-A Y
-B Y
-C Z
-A Y
-C X
-A Y
-C Z
-C X
-A Z
-C Y
-A X
-C Y
-C X
-B Y
-A Y
-C X
-C X
-A Y
-B X
-C Z
-C X
-A Z
-A Y
-C X
-C Z
-C Z
-C Z
-C Z
-C Y
-A Z
-C X
-C Z
-C Z
-A Y
-B X
-B X
-A Z
-C Z
-B Y
-C Z
-B Y
-C Z
-C Z
-B X
-C Z
-C Z
-B Z
-C Y
-A Y
-C Y
-B Z
-C Y
-C X
-C Z
-B X
-C Z
-A Y
-A Y
-C Y
-B Y
-A Z
-A Y
-A Z
-C Y
-B Y
-A Z
-C Z
-C Y
-C Y
-B Z
-B Z
-C X
-A Y
-B X
-B Y
-B Y
-C Z
-A Z
-C Z
-B X
-C Z
-C Y
-C Y
-C Y
-C X
-B X
-C Y
-A X
-B Y
-A Z
-C Z
-C Z
-C X
-A Z
-B X
-C Y
-C Z
-B Y
-A X
-B Y
-C Z
-A X
-C Z
-B Y
-C Y
-A Y
-C Y
-B Z
-C Z
-A Y
-A Y
-A Y
-A Y
-A Z
-C Z
-B Y
-C Z
-C Y
-B X
-A Y
-C Z
-C Z
-C X
-B Z
-C X
-A X
-B Z
-C Z
-A Y
-A Z
-A Y
-A Z
-C Y
-B Y
-A Z
-C Z
-B Y
-B Y
-C X
-A Z
-C X
-A Z
-C Y
-C X
-C Z
-A Y
-B Y
-B Z
-C Z
-B X
-B X
-C Z
-C Z
-B Z
-C X
-A Y
-B X
-B Y
-C Z
-C X
-B Z
-B X
-B X
-A Z
-C Z
-A Z
-C Z
-C Z
-A Y
-C Z
-A Y
-C Y
-A Y
-C Z
-C Z
-C Z
-A Y
-C Z
-A X
-B X
-B Y
-C X
-C Z
-A Y
-C Z
-C Y
-C Z
-C Y
-C X
-A Y
-C Z
-C Y
-C Y
-C Z
-A Y
-C Z
-C X
-C X
-B X
-B X
-C Z
-C Y
-A Z
-C Z
-C X
-C Z
-C Y
-B Y
-C Z
-C X
-B X
-C X
-A Y
-C Z
-A Z
-A Y
-C Z
-C Z
-B X
-B Y
-C Z
-C Z
-C Y
-C Y
-C Y
-C Z
-A Z
-B Y
-B X
-B Y
-A Y
-C Y
-A Y
-B Y
-B X
-B X
-C X
-C Z
-C Z
-C Z
-A X
-B Z
-A Z
-C Z
-C Z
-B X
-B X
-B Y
-C Z
-C X
-A Y
-C Z
-B X
-C Z
-B Y
-C X
-B Z
-C X
-A Y
-B X
-C X
-C Y
-A Y
-C Z
-A Y
-B X
-B Z
-A Y
-C Z
-C Y
-C Y
-C Y
-C Y
-C X
-C Z
-B Y
-B Y
-A Y
-B Z
-A Y
-C Y
-C Y
-B Z
-B Y
-C Z
-B Z
-C Z
-B X
-B Y
-A X
-B X
-A Y
-A Z
-C X
-C X
-C Z
-B Z
-B Y
-A Z
-A Y
-A Y
-C Z
-C Z
-C X
-B X
-C Z
-C Y
-C Y
-C Y
-C Y
-B Y
-A Y
-B Y
-A Z
-B X
-C Z
-C Y
-C Z
-B Y
-C X
-B X
-A X
-C Z
-C Z
-B Y
-A Y
-B Y
-B Y
-C Y
-A Z
-B Z
-C X
-A Y
-A Y
-C Y
-A Z
-C Z
-C X
-C X
-B Z
-C Y
-B X
-B Y
-C Z
-C Z
-C Y
-A Y
-A Y
-C X
-A Y
-C X
-B Y
-C X
-C Z
-A Z
-C X
-C Z
-C Z
-A Y
-C Z
-C Y
-B X
-A Y
-B Y
-C X
-A Y
-A Y
-C Z
-C Z
-B X
-C Y
-A Z
-A Y
-C Z
-C X
-C Z
-C Z
-A Y
-A Y
-C Z
-A Y
-C Z
-B Y
-C Y
-C Z
-C Z
-B Z
-C Y
-B Z
-A Z
-B Y
-B X
-B X
-C X
-C X
-A Z
-C Z
-B X
-A Z
-C X
-B Y
-B Y
-C Z
-A X
-C X
-C X
-B X
-A Y
-A Z
-C Z
-C Y
-C Z
-A Y
-B X
-C Z
-B Y
-C Y
-A Z
-B Y
-C Z
-B X
-B Z
-C Z
-A Y
-A Z
-C Z
-C Y
-A Z
-A Z
-C Z
-B X
-A Y
-A Y
-A Y
-A Y
-C Z
-A X
-B Z
-A Y
-B X
-C Z
-B X
-C Y
-B Y
-C Z
-B X
-B Z
-A Y
-C Z
-B Y
-C Z
-A Y
-A Z
-C Z
-B Y
-A Y
-C Y
-C X
-B Y
-B X
-B Y
-C Z
-C Z
-C Y
-A Y
-A Z
-C Y
-C Z
-C Z
-B Y
-B X
-C Z
-B X
-A Y
-C Y
-A Z
-A Y
-C Z
-B Y
-C Y
-A Y
-B X
-B Z
-C Y
-C Y
-A X
-C X
-C Y
-C Y
-C Z
-B Z
-C Z
-A Z
-A Y
-C Y
-C Y
-B Y
-C Y
-C Y
-C Y
-C Z
-B Z
-C Y
-C Y
-B X
-B Z
-B Z
-C Z
-B Z
-C X
-A X
-C Z
-B X
-C Z
-A Y
-A Z
-C Z
-C Z
-B X
-B X
-A Y
-A Z
-B X
-B Z
-C X
-A Y
-A Y
-C Z
-A Y
-B Y
-B Z
-A Z
-B X
-C Z
-C Z
-B Z
-B Y
-C Z
-C Y
-A Y
-C Z
-C Z
-A Y
-A Y
-B X
-B Y
-A Y
-C Y
-B Y
-C Z
-A Y
-C Z
-B Y
-B X
-C X
-A Y
-A Y
-B X
-C Y
-A Y
-A Y
-B Y
-C Z
-A Z
-C Y
-C Z
-C Z
-C Z
-A Z
-A Z
-A Y
-A Z
-C X
-B Z
-A Y
-A Y
-C Y
-C X
-B X
-C Y
-C Y
-C Z
-C Z
-A Z
-A Y
-C Y
-B Y
-C Y
-C Z
-C Z
-B Y
-B Y
-A Y
-B Y
-C Z
-A X
-B X
-C X
-C Z
-B Y
-A Z
-A Y
-B Z
-C Z
-B Y
-C Z
-C Y
-C Z
-A Y
-A Y
-A Z
-C X
-C Z
-A Y
-B Z
-B X
-C X
-C Z
-C Z
-C Z
-C Y
-C Z
-B X
-C Z
-C Z
-C Y
-C X
-B Z
-B X
-C Y
-C Z
-B Y
-A Y
-C Y
-A Z
-C Z
-B Z
-A Y
-A Z
-A Z
-A Y
-C Z
-C Y
-A Y
-B X
-B Y
-C Y
-C Z
-B Y
-B X
-B Y
-B Y
-C Y
-B Y
-C Z
-A Z
-C Y
-A Y
-B X
-B Z
-B Y
-A Y
-B X
-B Z
-A Y
-C Y
-B Y
-C Y
-C Y
-B Y
-C Z
-B Z
-A Z
-C Y
-C Z
-A X
-C Z
-C Z
-A Y
-C Z
-A Z
-A Y
-A X
-C Y
-B Y
-C Y
-C X
-B X
-A Y
-A Y
-A Y
-A Z
-A Y
-C X
-C Y
-C X
-A Y
-B X
-C Z
-A Z
-C Y
-B Z
-C Z
-B Y
-C X
-C Z
-C Z
-C Z
-C Z
-B X
-C Y
-B X
-A Y
-A Y
-C Z
-C X
-C Z
-C X
-C Y
-C Y
-B X
-C Z
-B X
-C Z
-C Z
-A Y
-C Z
-C Z
-C Z
-B Z
-C Z
-A X
-C Z
-C X
-C X
-C Y
-A Z
-B X
-A Y
-A Y
-A Y
-C Z
-C Y
-B X
-A Z
-C Z
-C Z
-C Z
-C Z
-B X
-C Z
-C Z
-C Y
-B Y
-A Z
-A Y
-A Z
-B X
-C Z
-C Y
-B Y
-A Y
-C Z
-B X
-A Y
-A Y
-C Z
-B Y
-C Y
-C Z
-C Y
-A Y
-B X
-C Z
-A X
-C Y
-C Z
-B Y
-A Y
-B X
-B Y
-C Y
-B Y
-C Z
-C Z
-A Z
-B X
-B Y
-B Y
-A Y
-B X
-C Y
-A Y
-B Y
-A Y
-C Y
-C Y
-B Y
-C Z
-C X
-B Z
-A Z
-A Y
-B X
-B Y
-C Y
-C Z
-A Z
-B Z
-B X
-C X
-C Y
-C X
-A Y
-A Y
-C Z
-C Y
-C X
-B X
-B Z
-C Z
-C Z
-B X
-C Y
-C Z
-C X
-C Y
-C X
-A Z
-C Y
-B Y
-B Y
-C Y
-A Z
-B Y
-C Z
-C Y
-A Z
-A Y
-C Z
-C X
-B Z
-C Z
-C Z
-A Y
-C Y
-C Z
-C Y
-B Z
-B Y
-B Y
-A Z
-C X
-A Y
-C Z
-C Y
-A Y
-C X
-C Z
-C Z
-B Z
-C X
-C Z
-A Y
-A Y
-B Y
-C X
-A Y
-C Z
-A Z
-C Y
-B Y
-A Y
-A Z
-C Z
-B X
-A Y
-C Z
-A Z
-C Z
-C Y
-C Z
-C Z
-C Y
-A Y
-C Z
-A Y
-B X
-B Z
-C Z
-C Z
-C Y
-C Z
-C Y
-C Y
-B X
-C X
-B Y
-C Y
-C Z
-B Y
-B Y
-A Z
-A Y
-B Y
-B X
-C Z
-C X
-A Y
-C Z
-C Y
-A Y
-C Z
-A X
-A Z
-A X
-C Z
-A X
-B Y
-C X
-C Y
-C Z
-B Z
-C Y
-B Y
-C Z
-B Y
-C Y
-C Z
-A Y
-B Y
-A Z
-B Z
-C Z
-A Y
-C Y
-B X
-C Y
-A X
-A Y
-C Z
-C X
-C X
-B Y
-B Z
-B Z
-C Y
-C Z
-B Y
-C Z
-C Y
-A Y
-B Y
-C Y
-C Z
-B Z
-C Z
-C Z
-A Y
-B Y
-A Y
-C Z
-A Y
-A Y
-C Y
-A Z
-C X
-A Y
-A Z
-B Y
-A Y
-B Y
-C Z
-B X
-C Y
-A Y
-C Y
-C Z
-A Y
-C Y
-C Y
-A Y
-C Y
-C Y
-B X
-A Y
-C Z
-A Y
-A Y
-A Y
-B Y
-C Y
-A Y
-C Z
-B X
-C Z
-A Y
-B Y
-C Y
-A Y
-C Y
-C Y
-C Y
-C Y
-C X
-C X
-A Y
-C X
-C Z
-B Z
-A Y
-B Y
-C Z
-B X
-C X
-A Y
-B X
-C Y
-B Z
-B Y
-C Y
-C Z
-C Z
-C X
-A Z
-C Y
-A Z
-C Z
-B Z
-A Y
-C Y
-B Y
-A Y
-B X
-C Z
-C Y
-A Y
-A Y
-A Z
-C Z
-C Z
-C Y
-C Z
-B X
-C Y
-C Z
-A Y
-B Y
-B X
-A Z
-C Z
-C Y
-C Z
-B Y
-C X
-C Y
-C Z
-B Y
-A Y
-A Z
-B X
-C Z
-C X
-A Y
-B X
-C Z
-C Y
-B Z
-C Z
-C Z
-C Z
-A Y
-A Y
-A X
-B Z
-A Y
-C Z
-C Z
-C Z
-B X
-B Y
-C Y
-B Y
-A Y
-A Z
-A Y
-A Y
-B Z
-A Y
-C X
-C Y
-A Z
-C Z
-C Y
-C Z
-B Y
-C Z
-B X
-B X
-A Y
-B Z
-B X
-B Y
-B Y
-B X
-B Y
-A Z
-C Z
-C Y
-C Y
-A Z
-B Y
-C Z
-A Y
-C Y
-A Y
-A Y
-B X
-C Y
-A Z
-B X
-B X
-A Y
-C Y
-A Y
-C Z
-B Y
-C Z
-C Y
-A X
-C Z
-C Z
-C Z
-A Y
-C Z
-C X
-C Z
-C X
-C Z
-B Y
-A Y
-A Y
-A Y
-B X
-B Y
-B Z
-B X
-C Z
-C Y
-B Z
-A Z
-A Z
-C X
-C Z
-B X
-A Z
-C X
-A X
-B Y
-B X
-B X
-C Z
-C Y
-A X
-C Y
-C Y
-C Y
-B X
-A Z
-B Y
-B X
-A Z
-C Z
-B X
-A Z
-A Y
-B Z
-B Y
-C X
-C Z
-B X
-C Y
-C Y
-C Y
-A Y
-C Z
-A Z
-A Z
-C Y
-C Y
-A Y
-C Z
-C Z
-B X
-C Z
-C Z
-B Y
-A Y
-C Z
-B X
-C Y
-C Y
-C Z
-C Z
-A Y
-C Z
-C Y
-B X
-C X
-A X
-A Z`
+// Round is one line of the strategy guide.
+type Round struct {
+	// Line is the round's 1-based line in the guide.
+	Line     int
+	Opponent string
+	// Column is the guide's second column. It is empty when ParseOpponents
+	// reads a line giving only the opponent's move.
+	Column string
+	// Lenient is set for rounds of Lenient guides, whose symbols match the
+	// rules ignoring case.
+	Lenient bool
+}
 
-func run(input string) (int, error) {
-	moves := map[string]int{
-		"A": 1,
-		"B": 2,
-		"C": 3,
-	}
-	results := map[string]int{
-		"X": 0,
-		"Y": 3,
-		"Z": 6,
-	}
-	outcomes := map[string]string{
-		"AX": "C",
-		"AY": "A",
-		"AZ": "B",
-		"BX": "A",
-		"BY": "B",
-		"BZ": "C",
-		"CX": "B",
-		"CY": "C",
-		"CZ": "A",
-	}
-	var total int
-	for _, line := range strings.Split(input, "\n") {
+// Guide is a parsed strategy guide.
+type Guide []Round
+
+// Mode selects how strictly a strategy guide is read.
+type Mode int
+
+const (
+	// Strict accepts only lines of exactly two fields, with symbols written
+	// exactly as the rules give them.
+	Strict Mode = iota
+	// Lenient also matches symbols ignoring case, and skips blank lines and
+	// comment lines starting with "#".
+	Lenient
+)
+
+// ParseGuide reads a strategy guide in the given mode.
+func ParseGuide(input string, m Mode) (Guide, error) {
+	return parseGuide(input, m, false)
+}
+
+// ParseOpponents reads the opponent's moves from a Lenient strategy guide,
+// also accepting lines that give only the opponent's move.
+func ParseOpponents(input string) (Guide, error) {
+	return parseGuide(input, Lenient, true)
+}
+
+func parseGuide(input string, m Mode, opponentsOnly bool) (Guide, error) {
+	var g Guide
+	for i, line := range strings.Split(input, "\n") {
 		fields := strings.Fields(line)
-		if len(fields) != 2 {
-			return 0, fmt.Errorf("line %q has %v fields", line, len(fields))
+		if m == Lenient {
+			if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+				continue
+			}
 		}
-		a, b := fields[0], fields[1]
-		var score int
-		v, ok := results[b]
-		if !ok {
-			return 0, fmt.Errorf("result %q unrecnogized", b)
+		if opponentsOnly && len(fields) == 1 {
+			fields = append(fields, "")
 		}
-		score += v
-		move, ok := outcomes[a+b]
-		if !ok {
-			return 0, fmt.Errorf("encoding %q unrecognized", a+b)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("line %v: %q has %v fields", i+1, line, len(fields))
 		}
-		v, ok = moves[move]
-		if !ok {
-			return 0, fmt.Errorf("move %q unrecognized", move)
+		g = append(g, Round{Line: i + 1, Opponent: fields[0], Column: fields[1], Lenient: m == Lenient})
+	}
+	if len(g) == 0 {
+		return nil, fmt.Errorf("guide has no rounds")
+	}
+	return g, nil
+}
+
+// Interpretation is a reading of the strategy guide's second column.
+type Interpretation int
+
+const (
+	// Shapes reads the second column as the shape to play, so X, Y and Z
+	// are rock, paper and scissors.
+	Shapes Interpretation = iota + 1
+	// Outcomes reads the second column as the outcome the round must have,
+	// so X, Y and Z are lose, draw and win.
+	Outcomes
+)
+
+var interpretations = map[string]Interpretation{
+	"shapes":   Shapes,
+	"outcomes": Outcomes,
+}
+
+// ParseInterpretation returns the interpretation with the given name,
+// "shapes" or "outcomes".
+func ParseInterpretation(name string) (Interpretation, error) {
+	i, ok := interpretations[name]
+	if !ok {
+		return 0, fmt.Errorf("interpretation %q unrecognized", name)
+	}
+	return i, nil
+}
+
+func (i Interpretation) String() string {
+	for name, j := range interpretations {
+		if i == j {
+			return name
 		}
-		score += v
-		total += score
 	}
-	return total, nil
+	return fmt.Sprintf("Interpretation(%d)", int(i))
 }
 
-func main() {
-	total, err := run(input)
+// Score returns the score from following the strictly read strategy guide
+// when its second column is read with interpretation i, under the Default
+// rules.
+func Score(input string, i Interpretation) (int, error) {
+	g, err := ParseGuide(input, Strict)
 	if err != nil {
-		log.Fatal(err)
+		return 0, err
 	}
-	fmt.Println(total)
+	return Default.Score(g, i)
+}
+
+// Scores returns the score from following the strictly read strategy guide
+// under both interpretations and the Default rules.
+func Scores(input string) (byShape, byOutcome int, err error) {
+	g, err := ParseGuide(input, Strict)
+	if err != nil {
+		return 0, 0, err
+	}
+	return Default.Scores(g)
+}
+
+// Part1 returns the score from following the strategy guide, where the second
+// column gives the shape to play.
+func Part1(input string) (int, error) {
+	return Score(input, Shapes)
+}
+
+// Part2 returns the score from following the strategy guide, where the second
+// column gives the outcome each round must have.
+func Part2(input string) (int, error) {
+	return Score(input, Outcomes)
 }
 
 func init() {
-	log.SetFlags(log.Flags() | log.Lshortfile)
+	registry.Register(2, 1, registry.Int(Part1))
+	registry.Register(2, 2, registry.Int(Part2))
 }
@@ -0,0 +1,440 @@
+package day2
+
+import (
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+
+	"advent2022/bench"
+	"advent2022/input"
+	"advent2022/registry"
+)
+
+func TestScores(t *testing.T) {
+	for _, name := range []string{"testdata/sample.txt", "testdata/input.txt"} {
+		in, err := input.ReadFile(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		byShape, byOutcome, err := Scores(in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, got := range map[Interpretation]int{Shapes: byShape, Outcomes: byOutcome} {
+			want, err := Score(in, i)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != want {
+				t.Errorf("%v: Scores() %v = %v, want %v", name, i, got, want)
+			}
+		}
+	}
+}
+
+func TestParseGuide(t *testing.T) {
+	lenient := "# opponent, then outcome\n\na y\n  B   x  \n# skipped\nc Z\n\n"
+	g, err := ParseGuide(lenient, Lenient)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Guide{{3, "a", "y", true}, {4, "B", "x", true}, {6, "c", "Z", true}}
+	if !reflect.DeepEqual(g, want) {
+		t.Errorf("ParseGuide(Lenient) = %v, want %v", g, want)
+	}
+	if _, err := ParseGuide(lenient, Strict); err == nil || err.Error() != `line 1: "# opponent, then outcome" has 4 fields` {
+		t.Errorf("ParseGuide(Strict) error = %v, want the comment on line 1 rejected", err)
+	}
+	g, err = ParseGuide("A Y\nb q", Lenient)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Default.Score(g, Outcomes); err == nil || err.Error() != `line 2: result "q" unrecognized` {
+		t.Errorf("Score() error = %v, want line 2 rejected", err)
+	}
+	if _, err := ParseGuide("A Y\nB X Z", Lenient); err == nil || err.Error() != `line 2: "B X Z" has 3 fields` {
+		t.Errorf("ParseGuide() error = %v, want line 2 rejected", err)
+	}
+	if _, err := ParseGuide("# nothing\n", Lenient); err == nil {
+		t.Errorf("ParseGuide() of only comments succeeded, want an error")
+	}
+	if _, err := ParseGuide("A Y\nB", Lenient); err == nil || err.Error() != `line 2: "B" has 1 fields` {
+		t.Errorf("ParseGuide() error = %v, want the truncated line 2 rejected", err)
+	}
+}
+
+func TestParseOpponents(t *testing.T) {
+	g, err := ParseOpponents("# opponents\na\nB x\n\nc\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Guide{{2, "a", "", true}, {3, "B", "x", true}, {5, "c", "", true}}
+	if !reflect.DeepEqual(g, want) {
+		t.Errorf("ParseOpponents() = %v, want %v", g, want)
+	}
+	if _, err := ParseOpponents("A X Y"); err == nil {
+		t.Errorf("ParseOpponents() of three fields succeeded, want an error")
+	}
+}
+
+func TestTrace(t *testing.T) {
+	in, err := input.ReadFile("testdata/sample.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	steps, err := Trace(in, Outcomes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Step{
+		{1, Play{"rock", "rock", "draw", 1, 3}, 4, Tally{0, 1, 0}},
+		{2, Play{"paper", "rock", "lose", 1, 0}, 5, Tally{0, 1, 1}},
+		{3, Play{"scissors", "rock", "win", 1, 6}, 12, Tally{1, 1, 1}},
+	}
+	if !reflect.DeepEqual(steps, want) {
+		t.Errorf("Trace() = %v, want %v", steps, want)
+	}
+	var b strings.Builder
+	if err := WriteCSV(&b, steps); err != nil {
+		t.Fatal(err)
+	}
+	wantCSV := `line,opponent,outcome,shape,shape_score,outcome_score,total,wins,draws,losses
+1,rock,draw,rock,1,3,4,0,1,0
+2,paper,lose,rock,1,0,5,0,1,1
+3,scissors,win,rock,1,6,12,1,1,1
+`
+	if b.String() != wantCSV {
+		t.Errorf("WriteCSV() = %q, want %q", b.String(), wantCSV)
+	}
+}
+
+func TestRPSLS(t *testing.T) {
+	rules, err := LoadRules("rules/rpsls.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		in   string
+		i    Interpretation
+		want int
+	}{
+		{"A Z\nE V\nC Y", Shapes, 11 + 1 + 4},
+		{"A Z\nE X\nD Y", Outcomes, 11 + 3 + 7},
+	}
+	for _, tt := range tests {
+		g, err := ParseGuide(tt.in, Strict)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := rules.Score(g, tt.i)
+		if err != nil {
+			t.Fatalf("Score(%q, %v): %v", tt.in, tt.i, err)
+		}
+		if got != tt.want {
+			t.Errorf("Score(%q, %v) = %v, want %v", tt.in, tt.i, got, tt.want)
+		}
+	}
+}
+
+func TestLowerCaseRules(t *testing.T) {
+	rules, err := ParseRules([]byte(`{
+		"shapes": [
+			{"name": "rock", "opponent": "a", "player": "x", "score": 1, "beats": ["scissors"]},
+			{"name": "paper", "opponent": "b", "player": "y", "score": 2, "beats": ["rock"]},
+			{"name": "scissors", "opponent": "c", "player": "z", "score": 3, "beats": ["paper"]}
+		],
+		"outcomes": [
+			{"name": "lose", "symbol": "x", "score": 0},
+			{"name": "draw", "symbol": "y", "score": 3},
+			{"name": "win", "symbol": "z", "score": 6}
+		]
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, in := range []string{"a y\nb x\nc z", "A Y\nb X\nC z"} {
+		g, err := ParseGuide(in, Lenient)
+		if err != nil {
+			t.Fatal(err)
+		}
+		byShape, byOutcome, err := rules.Scores(g)
+		if err != nil || byShape != 15 || byOutcome != 12 {
+			t.Errorf("Scores(%q) = %v, %v, %v, want 15, 12", in, byShape, byOutcome, err)
+		}
+	}
+	g, err := ParseGuide("A Y", Strict)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := rules.Score(g, Shapes); err == nil || err.Error() != `line 1: move "A" unrecognized` {
+		t.Errorf("Score(Strict) error = %v, want the upper-case move rejected", err)
+	}
+}
+
+func TestMultiCharacterSymbols(t *testing.T) {
+	// "A"+"BX" and "AB"+"X" must not be confused.
+	rules, err := ParseRules([]byte(`{
+		"shapes": [
+			{"name": "rock", "opponent": "A", "player": "R", "score": 1, "beats": ["scissors"]},
+			{"name": "paper", "opponent": "AB", "player": "P", "score": 2, "beats": ["rock"]},
+			{"name": "scissors", "opponent": "C", "player": "S", "score": 3, "beats": ["paper"]}
+		],
+		"outcomes": [
+			{"name": "lose", "symbol": "BX", "score": 0},
+			{"name": "draw", "symbol": "X", "score": 3},
+			{"name": "win", "symbol": "Z", "score": 6}
+		]
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for in, want := range map[string]int{"A BX": 3 + 0, "AB X": 2 + 3} {
+		g, err := ParseGuide(in, Strict)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, err := rules.Score(g, Outcomes); err != nil || got != want {
+			t.Errorf("Score(%q, Outcomes) = %v, %v, want %v", in, got, err, want)
+		}
+	}
+}
+
+func TestParseRulesUnbeatenShape(t *testing.T) {
+	// a beats b and c, and b beats c: nothing beats a, and c beats nothing.
+	_, err := ParseRules([]byte(`{
+		"shapes": [
+			{"name": "a", "opponent": "A", "player": "X", "score": 1, "beats": ["b", "c"]},
+			{"name": "b", "opponent": "B", "player": "Y", "score": 2, "beats": ["c"]},
+			{"name": "c", "opponent": "C", "player": "Z", "score": 3}
+		],
+		"outcomes": [
+			{"name": "lose", "symbol": "X", "score": 0},
+			{"name": "draw", "symbol": "Y", "score": 3},
+			{"name": "win", "symbol": "Z", "score": 6}
+		]
+	}`))
+	if want := `shape "a" must beat another shape and be beaten by one`; err == nil || err.Error() != want {
+		t.Errorf("ParseRules() error = %v, want %v", err, want)
+	}
+}
+
+func TestParseRulesErrors(t *testing.T) {
+	outcomes := `"outcomes": [{"name": "lose", "symbol": "X"}, {"name": "draw", "symbol": "Y"}, {"name": "win", "symbol": "Z"}]`
+	tests := []string{
+		`{"shapes": [{"name": "rock", "opponent": "A", "player": "X"}], ` + outcomes + `}`,
+		`{"shapes": [{"name": "rock", "opponent": "A", "player": "X", "beats": ["paper"]}, {"name": "paper", "opponent": "B", "player": "Y", "beats": ["rock"]}], ` + outcomes + `}`,
+		`{"shapes": [{"name": "rock", "opponent": "A", "player": "X"}, {"name": "paper", "opponent": "B", "player": "Y"}], ` + outcomes + `}`,
+		`{"shapes": [{"name": "rock", "opponent": "A", "player": "X", "beats": ["lizard"]}, {"name": "paper", "opponent": "B", "player": "Y"}], ` + outcomes + `}`,
+		`{"shapes": [{"name": "rock", "opponent": "A", "player": "X"}, {"name": "paper", "opponent": "A", "player": "Y", "beats": ["rock"]}], ` + outcomes + `}`,
+		`{"shapes": [{"name": "rock", "opponent": "A", "player": "X"}, {"name": "paper", "opponent": "B", "player": "Y", "beats": ["rock"]}], "outcomes": [{"name": "win", "symbol": "Z"}]}`,
+		`{"shapes": [{"name": "rock", "opponent": "A", "player": "X"}, {"name": "paper", "opponent": "a", "player": "Y", "beats": ["rock"]}], ` + outcomes + `}`,
+		`{"shapes": [{"name": "rock", "opponent": "A", "player": "X"}, {"name": "paper", "opponent": "B", "player": "x", "beats": ["rock"]}], ` + outcomes + `}`,
+		`{"shapes": [{"name": "rock", "opponent": "A", "player": "X"}, {"name": "paper", "opponent": "B", "player": "Y", "beats": ["rock"]}], "outcomes": [{"name": "lose", "symbol": "X"}, {"name": "draw", "symbol": "y"}, {"name": "win", "symbol": "Y"}]}`,
+	}
+	for _, tt := range tests {
+		if _, err := ParseRules([]byte(tt)); err == nil {
+			t.Errorf("ParseRules(%v) succeeded, want an error", tt)
+		}
+	}
+}
+
+// bruteForce returns the best score against opponents over every guide that
+// keeps to c, or false if none does.
+func bruteForce(r *Rules, opponents []Shape, c Constraints) (int, bool) {
+	best, found := 0, false
+	played := make([]int, len(opponents))
+	var try func(i, wins, run, total int)
+	try = func(i, wins, run, total int) {
+		if i == len(opponents) {
+			if !found || total > best {
+				best, found = total, true
+			}
+			return
+		}
+		for s, shape := range r.Shapes {
+			p := r.play(opponents[i], shape)
+			w := wins
+			if p.Outcome == "win" {
+				w++
+			}
+			n := 1
+			if i > 0 && played[i-1] == s {
+				n = run + 1
+			}
+			if c.MaxWins != Unlimited && w > c.MaxWins || c.MaxRun != Unlimited && n > c.MaxRun {
+				continue
+			}
+			played[i] = s
+			try(i+1, w, n, total+p.Score())
+		}
+	}
+	try(0, 0, 0, 0)
+	return best, found
+}
+
+// opponents returns the opponent's shapes in the first column of input.
+func opponents(t *testing.T, input string) []Shape {
+	t.Helper()
+	g, err := ParseOpponents(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var shapes []Shape
+	for _, rd := range g {
+		s, ok := Default.move(rd)
+		if !ok {
+			t.Fatalf("line %v: move %q unrecognized", rd.Line, rd.Opponent)
+		}
+		shapes = append(shapes, s)
+	}
+	return shapes
+}
+
+func TestOptimize(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	symbols := []string{"A", "B", "C"}
+	for i := 0; i < 300; i++ {
+		var lines []string
+		for j := 1 + r.Intn(7); j > 0; j-- {
+			lines = append(lines, symbols[r.Intn(3)])
+		}
+		in := strings.Join(lines, "\n")
+		c := Constraints{MaxWins: r.Intn(9) - 1, MaxRun: r.Intn(5) - 1}
+		if c.MaxRun == 0 {
+			c.MaxRun = Unlimited
+		}
+		want, ok := bruteForce(Default, opponents(t, in), c)
+		total, guide, err := Optimize(in, c)
+		if !ok {
+			if err == nil {
+				t.Errorf("Optimize(%q, %+v) succeeded, want an error", in, c)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Optimize(%q, %+v): %v", in, c, err)
+		}
+		if total != want {
+			t.Errorf("Optimize(%q, %+v) = %v, want %v", in, c, total, want)
+		}
+		steps, err := Trace(guide, Shapes)
+		if err != nil {
+			t.Fatalf("Trace(%q): %v", guide, err)
+		}
+		last := steps[len(steps)-1]
+		if last.Total != total {
+			t.Errorf("guide %q scores %v, want %v", guide, last.Total, total)
+		}
+		if c.MaxWins != Unlimited && last.Tally.Wins > c.MaxWins {
+			t.Errorf("guide %q wins %v rounds, want at most %v", guide, last.Tally.Wins, c.MaxWins)
+		}
+		run := 0
+		for j, step := range steps {
+			if j > 0 && step.Shape == steps[j-1].Shape {
+				run++
+			} else {
+				run = 1
+			}
+			if c.MaxRun != Unlimited && run > c.MaxRun {
+				t.Errorf("guide %q plays %v %v times in a row, want at most %v", guide, step.Shape, run, c.MaxRun)
+			}
+		}
+	}
+}
+
+func TestOptimizeInput(t *testing.T) {
+	in, err := input.ReadFile("testdata/input.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Without limits each round is played on its own for the most points.
+	var want int
+	for _, opponent := range opponents(t, in) {
+		var best int
+		for _, shape := range Default.Shapes {
+			if p := Default.play(opponent, shape); p.Score() > best {
+				best = p.Score()
+			}
+		}
+		want += best
+	}
+	total, _, err := Optimize(in, Constraints{MaxWins: Unlimited, MaxRun: Unlimited})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != want {
+		t.Errorf("Optimize() = %v, want %v", total, want)
+	}
+	limited, _, err := Optimize(in, Constraints{MaxWins: 100, MaxRun: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if limited >= total {
+		t.Errorf("Optimize() with limits = %v, want less than %v", limited, total)
+	}
+}
+
+func TestSimulate(t *testing.T) {
+	rocks, err := Default.FixedGuide(Guide{{Line: 1, Opponent: "A", Column: "X"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []Strategy{LastMoveBeater{}, FrequencyCounter{}} {
+		m, err := Default.Simulate(rocks, s, 200, 10, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// After a random first round, every round is paper against rock.
+		if m.Mean[1] < 9*8+1 || m.Mean[1] > 9*8+9 || m.Mean[0] < 9*1+1 || m.Mean[0] > 9*1+7 {
+			t.Errorf("%v against rocks: means = %v, want last 9 rounds won by paper", s.Name(), m.Mean)
+		}
+		if m.Variance[1] == 0 {
+			t.Errorf("%v against rocks: variance = 0, want the random first round to vary", s.Name())
+		}
+		again, err := Default.Simulate(rocks, s, 200, 10, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if again != m {
+			t.Errorf("%v against rocks with the same seed = %v, then %v", s.Name(), m, again)
+		}
+	}
+}
+
+func TestTournament(t *testing.T) {
+	in, err := input.ReadFile("testdata/input.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	g, err := ParseGuide(in, Strict)
+	if err != nil {
+		t.Fatal(err)
+	}
+	guide, err := Default.FixedGuide(g)
+	if err != nil {
+		t.Fatal(err)
+	}
+	strategies := []Strategy{guide, Random{}, FrequencyCounter{}, LastMoveBeater{}}
+	results, err := Default.Tournament(strategies, 20, 50, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 6 {
+		t.Fatalf("Tournament() has %v matchups, want 6", len(results))
+	}
+	for _, m := range results {
+		// Each round hands out between 2 and 12 points.
+		if sum := m.Mean[0] + m.Mean[1]; sum < 50*2 || sum > 50*12 {
+			t.Errorf("%v: means add up to %v, want between %v and %v", m.Names, sum, 50*2, 50*12)
+		}
+	}
+}
+
+func BenchmarkPart1(b *testing.B) {
+	bench.File(b, registry.Int(Part1), "testdata/input.txt")
+}
+
+func BenchmarkPart2(b *testing.B) {
+	bench.File(b, registry.Int(Part2), "testdata/input.txt")
+}
@@ -0,0 +1,333 @@
+package day2
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Shape is one shape a player can throw.
+type Shape struct {
+	Name string `json:"name"`
+	// Opponent is the symbol for the shape in the guide's first column.
+	Opponent string `json:"opponent"`
+	// Player is the symbol for the shape in the guide's second column when
+	// it names the shape to play.
+	Player string `json:"player"`
+	// Score is the score for playing the shape.
+	Score int `json:"score"`
+	// Beats names the shapes that this shape beats.
+	Beats []string `json:"beats"`
+}
+
+// Outcome is one way a round can end, from the player's point of view.
+type Outcome struct {
+	// Name is "lose", "draw" or "win".
+	Name string `json:"name"`
+	// Symbol is the symbol for the outcome in the guide's second column
+	// when it names the outcome.
+	Symbol string `json:"symbol"`
+	// Score is the score for a round with this outcome.
+	Score int `json:"score"`
+}
+
+// Rules describe a game in the rock paper scissors family: its shapes, which
+// shape beats which, and how rounds are scored.
+type Rules struct {
+	Shapes   []Shape   `json:"shapes"`
+	Outcomes []Outcome `json:"outcomes"`
+
+	// moves maps the opponent's symbols to shapes.
+	moves map[string]Shape
+	// shapes maps the player's symbols to shapes.
+	shapes map[string]Shape
+	// results maps outcome symbols to outcomes.
+	results map[string]Outcome
+	// byName maps each outcome's name to the outcome.
+	byName map[string]Outcome
+	// beats holds the pairs of shape names where the first beats the second.
+	beats map[[2]string]bool
+	// outcomes maps the opponent's symbol and the outcome symbol to the
+	// shape that has to be played.
+	outcomes map[[2]string]Shape
+	// foldedMoves, foldedShapes and foldedResults are moves, shapes and
+	// results keyed by lower-cased symbol, for rounds of Lenient guides.
+	foldedMoves   map[string]Shape
+	foldedShapes  map[string]Shape
+	foldedResults map[string]Outcome
+}
+
+//go:embed rules/rps.json
+var defaultRules []byte
+
+// Default is the rules of rock paper scissors as the strategy guide uses
+// them.
+var Default = mustParseRules(defaultRules)
+
+func mustParseRules(b []byte) *Rules {
+	r, err := ParseRules(b)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// LoadRules reads rules from the named JSON file.
+func LoadRules(name string) (*Rules, error) {
+	b, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	r, err := ParseRules(b)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %v", name, err)
+	}
+	return r, nil
+}
+
+// ParseRules parses rules written as JSON and derives the tables used to
+// score rounds from them. Every pair of distinct shapes must have exactly
+// one winner, every shape must both beat and be beaten by another, and symbols of the same kind must differ when case is ignored.
+func ParseRules(b []byte) (*Rules, error) {
+	r := &Rules{}
+	if err := json.Unmarshal(b, r); err != nil {
+		return nil, fmt.Errorf("parse rules: %v", err)
+	}
+	if len(r.Shapes) < 3 {
+		return nil, fmt.Errorf("rules have %v shapes, want at least 3", len(r.Shapes))
+	}
+	r.moves = map[string]Shape{}
+	r.shapes = map[string]Shape{}
+	r.foldedMoves = map[string]Shape{}
+	r.foldedShapes = map[string]Shape{}
+	names := map[string]bool{}
+	for _, s := range r.Shapes {
+		if names[s.Name] {
+			return nil, fmt.Errorf("shape %q defined twice", s.Name)
+		}
+		names[s.Name] = true
+		if _, ok := r.moves[s.Opponent]; ok || s.Opponent == "" {
+			return nil, fmt.Errorf("shape %q has opponent symbol %q that is empty or taken", s.Name, s.Opponent)
+		}
+		r.moves[s.Opponent] = s
+		if _, ok := r.shapes[s.Player]; ok || s.Player == "" {
+			return nil, fmt.Errorf("shape %q has player symbol %q that is empty or taken", s.Name, s.Player)
+		}
+		r.shapes[s.Player] = s
+		if t, ok := r.foldedMoves[fold(s.Opponent)]; ok {
+			return nil, fmt.Errorf("opponent symbols %q and %q differ only in case", t.Opponent, s.Opponent)
+		}
+		r.foldedMoves[fold(s.Opponent)] = s
+		if t, ok := r.foldedShapes[fold(s.Player)]; ok {
+			return nil, fmt.Errorf("player symbols %q and %q differ only in case", t.Player, s.Player)
+		}
+		r.foldedShapes[fold(s.Player)] = s
+	}
+	r.beats = map[[2]string]bool{}
+	for _, s := range r.Shapes {
+		for _, beaten := range s.Beats {
+			if !names[beaten] {
+				return nil, fmt.Errorf("shape %q beats unknown shape %q", s.Name, beaten)
+			}
+			if beaten == s.Name {
+				return nil, fmt.Errorf("shape %q beats itself", s.Name)
+			}
+			r.beats[[2]string{s.Name, beaten}] = true
+		}
+	}
+	for _, a := range r.Shapes {
+		for _, b := range r.Shapes {
+			if a.Name >= b.Name {
+				continue
+			}
+			x, y := r.beats[[2]string{a.Name, b.Name}], r.beats[[2]string{b.Name, a.Name}]
+			if x == y {
+				return nil, fmt.Errorf("shapes %q and %q need exactly one winner", a.Name, b.Name)
+			}
+		}
+	}
+	// Without these, some outcome could not be reached against a shape.
+	for _, s := range r.Shapes {
+		beaten, beats := false, len(s.Beats) > 0
+		for _, t := range r.Shapes {
+			beaten = beaten || r.beats[[2]string{t.Name, s.Name}]
+		}
+		if !beaten || !beats {
+			return nil, fmt.Errorf("shape %q must beat another shape and be beaten by one", s.Name)
+		}
+	}
+	r.results = map[string]Outcome{}
+	r.foldedResults = map[string]Outcome{}
+	r.byName = map[string]Outcome{}
+	for _, o := range r.Outcomes {
+		switch o.Name {
+		case "lose", "draw", "win":
+		default:
+			return nil, fmt.Errorf("outcome %q unrecognized", o.Name)
+		}
+		if _, ok := r.byName[o.Name]; ok {
+			return nil, fmt.Errorf("outcome %q defined twice", o.Name)
+		}
+		if _, ok := r.results[o.Symbol]; ok || o.Symbol == "" {
+			return nil, fmt.Errorf("outcome %q has symbol %q that is empty or taken", o.Name, o.Symbol)
+		}
+		r.byName[o.Name] = o
+		r.results[o.Symbol] = o
+		if t, ok := r.foldedResults[fold(o.Symbol)]; ok {
+			return nil, fmt.Errorf("outcome symbols %q and %q differ only in case", t.Symbol, o.Symbol)
+		}
+		r.foldedResults[fold(o.Symbol)] = o
+	}
+	if len(r.byName) != 3 {
+		return nil, fmt.Errorf("rules have %v outcomes, want lose, draw and win", len(r.byName))
+	}
+	// When several shapes give the wanted outcome, play the one scoring the
+	// most, and the first listed of those.
+	r.outcomes = map[[2]string]Shape{}
+	for _, opponent := range r.Shapes {
+		for _, o := range r.Outcomes {
+			key := [2]string{opponent.Opponent, o.Symbol}
+			for _, s := range r.Shapes {
+				if r.outcome(opponent, s) != o.Name {
+					continue
+				}
+				if best, ok := r.outcomes[key]; !ok || s.Score > best.Score {
+					r.outcomes[key] = s
+				}
+			}
+		}
+	}
+	return r, nil
+}
+
+// fold returns the key of symbol s in the folded tables.
+func fold(s string) string {
+	return strings.ToLower(s)
+}
+
+// lookup returns the value of symbol s in exact, or in folded by its folded
+// key if the round is lenient.
+func lookup[V any](exact, folded map[string]V, s string, lenient bool) (V, bool) {
+	v, ok := exact[s]
+	if !ok && lenient {
+		v, ok = folded[fold(s)]
+	}
+	return v, ok
+}
+
+// move returns the opponent's shape in round rd.
+func (r *Rules) move(rd Round) (Shape, bool) {
+	return lookup(r.moves, r.foldedMoves, rd.Opponent, rd.Lenient)
+}
+
+// shape returns the shape that round rd's second column names.
+func (r *Rules) shape(rd Round) (Shape, bool) {
+	return lookup(r.shapes, r.foldedShapes, rd.Column, rd.Lenient)
+}
+
+// result returns the outcome that round rd's second column names.
+func (r *Rules) result(rd Round) (Outcome, bool) {
+	return lookup(r.results, r.foldedResults, rd.Column, rd.Lenient)
+}
+
+// outcome returns the name of the outcome of playing shape against the
+// opponent's shape.
+func (r *Rules) outcome(opponent, shape Shape) string {
+	switch {
+	case opponent.Name == shape.Name:
+		return "draw"
+	case r.beats[[2]string{shape.Name, opponent.Name}]:
+		return "win"
+	}
+	return "lose"
+}
+
+// Play is one scored round of the game.
+type Play struct {
+	// Opponent is the name of the opponent's shape.
+	Opponent string
+	// Shape is the name of the shape played.
+	Shape string
+	// Outcome is the name of the round's outcome.
+	Outcome      string
+	ShapeScore   int
+	OutcomeScore int
+}
+
+// Score returns the total score of the round.
+func (p Play) Score() int {
+	return p.ShapeScore + p.OutcomeScore
+}
+
+// play scores shape played against the opponent's shape.
+func (r *Rules) play(opponent, shape Shape) Play {
+	o := r.byName[r.outcome(opponent, shape)]
+	return Play{
+		Opponent:     opponent.Name,
+		Shape:        shape.Name,
+		Outcome:      o.Name,
+		ShapeScore:   shape.Score,
+		OutcomeScore: o.Score,
+	}
+}
+
+// playRound scores one round of the guide read with interpretation i.
+func (r *Rules) playRound(rd Round, i Interpretation) (Play, error) {
+	opponent, ok := r.move(rd)
+	if !ok {
+		return Play{}, fmt.Errorf("line %v: move %q unrecognized", rd.Line, rd.Opponent)
+	}
+	switch i {
+	case Shapes:
+		shape, ok := r.shape(rd)
+		if !ok {
+			return Play{}, fmt.Errorf("line %v: shape %q unrecognized", rd.Line, rd.Column)
+		}
+		return r.play(opponent, shape), nil
+	case Outcomes:
+		o, ok := r.result(rd)
+		if !ok {
+			return Play{}, fmt.Errorf("line %v: result %q unrecognized", rd.Line, rd.Column)
+		}
+		shape, ok := r.outcomes[[2]string{opponent.Opponent, o.Symbol}]
+		if !ok {
+			return Play{}, fmt.Errorf("line %v: encoding %q unrecognized", rd.Line, rd.Opponent+" "+rd.Column)
+		}
+		return r.play(opponent, shape), nil
+	}
+	return Play{}, fmt.Errorf("interpretation %v unrecognized", i)
+}
+
+// Score returns the score from following the strategy guide when its second
+// column is read with interpretation i.
+func (r *Rules) Score(g Guide, i Interpretation) (int, error) {
+	var total int
+	for _, rd := range g {
+		p, err := r.playRound(rd, i)
+		if err != nil {
+			return 0, err
+		}
+		total += p.Score()
+	}
+	return total, nil
+}
+
+// Scores returns the score from following the strategy guide under both
+// interpretations, reading the guide once.
+func (r *Rules) Scores(g Guide) (byShape, byOutcome int, err error) {
+	for _, rd := range g {
+		p, err := r.playRound(rd, Shapes)
+		if err != nil {
+			return 0, 0, err
+		}
+		byShape += p.Score()
+		p, err = r.playRound(rd, Outcomes)
+		if err != nil {
+			return 0, 0, err
+		}
+		byOutcome += p.Score()
+	}
+	return byShape, byOutcome, nil
+}
@@ -0,0 +1,162 @@
+package day2
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// Strategy chooses the shapes a player throws over a match.
+type Strategy interface {
+	Name() string
+	// Play returns the shape to throw next. history holds the shapes the
+	// opponent threw in the earlier rounds of the match.
+	Play(r *Rules, history []Shape, rng *rand.Rand) Shape
+}
+
+// beat returns the shape that beats s, preferring the one scoring the most.
+// ParseRules ensures that some shape does.
+func (r *Rules) beat(s Shape) Shape {
+	return r.outcomes[[2]string{s.Opponent, r.byName["win"].Symbol}]
+}
+
+// FixedGuide plays the shapes named by a strategy guide's second column in
+// order, starting over when it runs out.
+type FixedGuide struct {
+	Shapes []Shape
+}
+
+// FixedGuide returns the strategy that plays the second column of g read
+// with the Shapes interpretation.
+func (r *Rules) FixedGuide(g Guide) (*FixedGuide, error) {
+	f := &FixedGuide{}
+	for _, rd := range g {
+		s, ok := r.shape(rd)
+		if !ok {
+			return nil, fmt.Errorf("line %v: shape %q unrecognized", rd.Line, rd.Column)
+		}
+		f.Shapes = append(f.Shapes, s)
+	}
+	return f, nil
+}
+
+func (g *FixedGuide) Name() string { return "guide" }
+
+func (g *FixedGuide) Play(r *Rules, history []Shape, rng *rand.Rand) Shape {
+	return g.Shapes[len(history)%len(g.Shapes)]
+}
+
+// Random throws a shape chosen uniformly at random.
+type Random struct{}
+
+func (Random) Name() string { return "random" }
+
+func (Random) Play(r *Rules, history []Shape, rng *rand.Rand) Shape {
+	return r.Shapes[rng.Intn(len(r.Shapes))]
+}
+
+// FrequencyCounter beats the shape the opponent has thrown most often,
+// throwing at random until it has seen one.
+type FrequencyCounter struct{}
+
+func (FrequencyCounter) Name() string { return "frequency" }
+
+func (FrequencyCounter) Play(r *Rules, history []Shape, rng *rand.Rand) Shape {
+	if len(history) == 0 {
+		return Random{}.Play(r, history, rng)
+	}
+	counts := map[string]int{}
+	for _, s := range history {
+		counts[s.Name]++
+	}
+	// Break ties by the order of the rules so that the choice does not
+	// depend on map iteration.
+	most := r.Shapes[0]
+	for _, s := range r.Shapes[1:] {
+		if counts[s.Name] > counts[most.Name] {
+			most = s
+		}
+	}
+	return r.beat(most)
+}
+
+// LastMoveBeater beats the shape the opponent threw last, throwing at random
+// in the first round.
+type LastMoveBeater struct{}
+
+func (LastMoveBeater) Name() string { return "last-move" }
+
+func (LastMoveBeater) Play(r *Rules, history []Shape, rng *rand.Rand) Shape {
+	if len(history) == 0 {
+		return Random{}.Play(r, history, rng)
+	}
+	return r.beat(history[len(history)-1])
+}
+
+// Matchup summarizes the scores two strategies got from playing each other.
+type Matchup struct {
+	// Names are the names of the two strategies.
+	Names [2]string
+	// Mean and Variance describe the score each strategy got per match.
+	Mean     [2]float64
+	Variance [2]float64
+}
+
+// Simulate plays the given number of matches of the given number of rounds
+// between a and b and summarizes their scores. The same seed always gives the
+// same result.
+func (r *Rules) Simulate(a, b Strategy, matches, rounds int, seed int64) (Matchup, error) {
+	if matches < 2 || rounds < 1 {
+		return Matchup{}, fmt.Errorf("%v matches of %v rounds, want at least 2 matches of 1 round", matches, rounds)
+	}
+	rng := rand.New(rand.NewSource(seed))
+	scores := [2][]float64{}
+	for m := 0; m < matches; m++ {
+		var histories [2][]Shape
+		var totals [2]int
+		for i := 0; i < rounds; i++ {
+			x := a.Play(r, histories[0], rng)
+			y := b.Play(r, histories[1], rng)
+			totals[0] += r.play(y, x).Score()
+			totals[1] += r.play(x, y).Score()
+			histories[0] = append(histories[0], y)
+			histories[1] = append(histories[1], x)
+		}
+		scores[0] = append(scores[0], float64(totals[0]))
+		scores[1] = append(scores[1], float64(totals[1]))
+	}
+	m := Matchup{Names: [2]string{a.Name(), b.Name()}}
+	for i := range scores {
+		m.Mean[i], m.Variance[i] = meanVariance(scores[i])
+	}
+	return m, nil
+}
+
+// meanVariance returns the mean and the sample variance of xs.
+func meanVariance(xs []float64) (float64, float64) {
+	var sum float64
+	for _, x := range xs {
+		sum += x
+	}
+	mean := sum / float64(len(xs))
+	var squares float64
+	for _, x := range xs {
+		squares += (x - mean) * (x - mean)
+	}
+	return mean, squares / float64(len(xs)-1)
+}
+
+// Tournament plays every pair of strategies against each other with
+// Simulate, giving each pairing its own seed derived from seed.
+func (r *Rules) Tournament(strategies []Strategy, matches, rounds int, seed int64) ([]Matchup, error) {
+	var results []Matchup
+	for i, a := range strategies {
+		for _, b := range strategies[i+1:] {
+			m, err := r.Simulate(a, b, matches, rounds, seed+int64(len(results)))
+			if err != nil {
+				return nil, err
+			}
+			results = append(results, m)
+		}
+	}
+	return results, nil
+}
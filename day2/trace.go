@@ -0,0 +1,82 @@
+package day2
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+)
+
+// Tally counts the outcomes of the rounds played so far.
+type Tally struct {
+	Wins   int
+	Draws  int
+	Losses int
+}
+
+// Step is one round of a traced strategy guide.
+type Step struct {
+	// Line is the round's 1-based line in the guide.
+	Line int
+	Play
+	// Total is the score of this round and every round before it.
+	Total int
+	// Tally counts the outcomes of this round and every round before it.
+	Tally Tally
+}
+
+// Trace scores the strategy guide like Score but returns the breakdown of
+// every round.
+func (r *Rules) Trace(g Guide, i Interpretation) ([]Step, error) {
+	var steps []Step
+	var total int
+	var tally Tally
+	for _, rd := range g {
+		p, err := r.playRound(rd, i)
+		if err != nil {
+			return nil, err
+		}
+		total += p.Score()
+		switch p.Outcome {
+		case "win":
+			tally.Wins++
+		case "draw":
+			tally.Draws++
+		case "lose":
+			tally.Losses++
+		}
+		steps = append(steps, Step{Line: rd.Line, Play: p, Total: total, Tally: tally})
+	}
+	return steps, nil
+}
+
+// Trace is Rules.Trace for the strictly read guide under the Default rules.
+func Trace(input string, i Interpretation) ([]Step, error) {
+	g, err := ParseGuide(input, Strict)
+	if err != nil {
+		return nil, err
+	}
+	return Default.Trace(g, i)
+}
+
+// WriteCSV writes steps to w as CSV with a header row. The running total and
+// tally in the last row summarize the whole guide.
+func WriteCSV(w io.Writer, steps []Step) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"line", "opponent", "outcome", "shape", "shape_score", "outcome_score", "total", "wins", "draws", "losses"})
+	for _, s := range steps {
+		cw.Write([]string{
+			strconv.Itoa(s.Line),
+			s.Opponent,
+			s.Outcome,
+			s.Shape,
+			strconv.Itoa(s.ShapeScore),
+			strconv.Itoa(s.OutcomeScore),
+			strconv.Itoa(s.Total),
+			strconv.Itoa(s.Tally.Wins),
+			strconv.Itoa(s.Tally.Draws),
+			strconv.Itoa(s.Tally.Losses),
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
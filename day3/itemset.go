@@ -0,0 +1,76 @@
+package day3
+
+import "math/bits"
+
+// ItemSet is a set of item types, stored as a bitmask indexed by priority.
+type ItemSet uint64
+
+// maxPriority is the priority of the last item type, 'Z'.
+const maxPriority = 52
+
+// allItems is the set of every item type.
+const allItems ItemSet = 1<<(maxPriority+1) - 2
+
+// Add adds item r to the set.
+func (s *ItemSet) Add(r rune) error {
+	p, err := priority(r)
+	if err != nil {
+		return err
+	}
+	*s |= 1 << p
+	return nil
+}
+
+// Contains reports whether item r is in the set.
+func (s ItemSet) Contains(r rune) bool {
+	p, err := priority(r)
+	if err != nil {
+		return false
+	}
+	return s&(1<<p) != 0
+}
+
+// Intersect returns the items in both s and t.
+func (s ItemSet) Intersect(t ItemSet) ItemSet {
+	return s & t
+}
+
+// Union returns the items in either s or t.
+func (s ItemSet) Union(t ItemSet) ItemSet {
+	return s | t
+}
+
+// Len returns the number of items in the set.
+func (s ItemSet) Len() int {
+	return bits.OnesCount64(uint64(s))
+}
+
+// Items returns the items in the set in order of priority.
+func (s ItemSet) Items() []rune {
+	items := make([]rune, 0, s.Len())
+	for s != 0 {
+		p := bits.TrailingZeros64(uint64(s))
+		items = append(items, item(p))
+		s &^= 1 << p
+	}
+	return items
+}
+
+// Priority returns the sum of the priorities of the items in the set.
+func (s ItemSet) Priority() int {
+	var total int
+	for s != 0 {
+		p := bits.TrailingZeros64(uint64(s))
+		total += p
+		s &^= 1 << p
+	}
+	return total
+}
+
+// item returns the item with priority p.
+func item(p int) rune {
+	if p > 26 {
+		return rune('A' + p - 27)
+	}
+	return rune('a' + p - 1)
+}
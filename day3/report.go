@@ -0,0 +1,67 @@
+package day3
+
+// Rucksack is one rucksack of a Report.
+type Rucksack struct {
+	// Line is the rucksack's 1-based line in the input.
+	Line  int
+	Items string
+	// Split reports whether the items divide into two equal compartments.
+	Split bool
+	// Shared is the set of items found in both compartments. It is empty
+	// unless Split is set.
+	Shared ItemSet
+}
+
+// Group is one group of elves of a Report.
+type Group struct {
+	Rucksacks []Rucksack
+	// Common is the set of items found in every rucksack of the group.
+	Common ItemSet
+}
+
+// Badge returns the group's badge, or false if the group does not have
+// exactly one common item.
+func (g Group) Badge() (rune, bool) {
+	if g.Common.Len() != 1 {
+		return 0, false
+	}
+	return g.Common.Items()[0], true
+}
+
+// Priority returns the sum of the priorities of the group's common items,
+// which is what BadgesOf adds for the group.
+func (g Group) Priority() int {
+	return g.Common.Priority()
+}
+
+// Report returns the breakdown of every group of n elves: each rucksack with
+// the items shared by its compartments, and the items common to the group.
+// Like BadgesOf, it accepts rucksacks that do not split evenly.
+func Report(input string, n int) ([]Group, error) {
+	groups, err := group(parse(input), n)
+	if err != nil {
+		return nil, err
+	}
+	var report []Group
+	for _, rucksacks := range groups {
+		g := Group{Common: allItems}
+		for _, r := range rucksacks {
+			items, err := r.set(0, len(r.items))
+			if err != nil {
+				return nil, err
+			}
+			g.Common = g.Common.Intersect(items)
+			rs := Rucksack{Line: r.line, Items: r.items}
+			if _, err := r.half(); err == nil {
+				a, b, err := r.split()
+				if err != nil {
+					return nil, err
+				}
+				rs.Split, rs.Shared = true, a.Intersect(b)
+			}
+			g.Rucksacks = append(g.Rucksacks, rs)
+		}
+		report = append(report, g)
+	}
+	return report, nil
+}
@@ -1,359 +1,207 @@
-package main
+package day3
 
 import (
 	"fmt"
-	"log"
 	"strings"
 	"unicode"
+	"unicode/utf8"
+
+	"advent2022/registry"
 )
 
+// priority returns the priority of item r: 1 through 26 for a through z and
+// 27 through 52 for A through Z.
 func priority(r rune) (int, error) {
-	if unicode.IsUpper(r) {
-		return int(r) - 38, nil
+	switch {
+	case 'a' <= r && r <= 'z':
+		return int(r-'a') + 1, nil
+	case 'A' <= r && r <= 'Z':
+		return int(r-'A') + 27, nil
 	}
-	if unicode.IsLower(r) {
-		return int(r) - 96, nil
+	return 0, fmt.Errorf("invalid item %q", r)
+}
+
+// rucksack is the list of items on one line of the input.
+type rucksack struct {
+	line int
+	// indent is the number of runes of whitespace before the items.
+	indent int
+	items  string
+}
+
+// parse returns the non-blank lines of input as rucksacks.
+func parse(input string) []rucksack {
+	var rucksacks []rucksack
+	for i, line := range strings.Split(input, "\n") {
+		items := strings.TrimLeftFunc(line, unicode.IsSpace)
+		indent := utf8.RuneCountInString(line[:len(line)-len(items)])
+		items = strings.TrimRightFunc(items, unicode.IsSpace)
+		if items == "" {
+			continue
+		}
+		rucksacks = append(rucksacks, rucksack{line: i + 1, indent: indent, items: items})
 	}
-	return 0, fmt.Errorf("unrecognized rune %v", r)
+	return rucksacks
 }
 
-func run(input string) (int, error) {
-	var total int
-	lines := strings.Fields(input)
-	for i := 0; i < len(lines); i += 3 {
-		a, b, c := lines[i], lines[i+1], lines[i+2]
-		x, y, z := map[rune]struct{}{}, map[rune]struct{}{}, map[rune]struct{}{}
-		for j := 0; j < len(a); j++ {
-			x[rune(a[j])] = struct{}{}
+// set returns the set of items in r.items[lo:hi], which are byte offsets.
+// Invalid items are reported by line and column.
+func (r rucksack) set(lo, hi int) (ItemSet, error) {
+	var s ItemSet
+	for i, item := range r.items[lo:hi] {
+		if err := s.Add(item); err != nil {
+			column := r.indent + utf8.RuneCountInString(r.items[:lo+i]) + 1
+			return 0, fmt.Errorf("line %v, column %v: %v", r.line, column, err)
 		}
-		for j := 0; j < len(b); j++ {
-			y[rune(b[j])] = struct{}{}
+	}
+	return s, nil
+}
+
+// half returns the byte offset of the start of the second compartment of r,
+// or an error if its items do not split into two equal compartments.
+func (r rucksack) half() (int, error) {
+	n := utf8.RuneCountInString(r.items)
+	if n%2 != 0 {
+		return 0, fmt.Errorf("line %v: %q has %v items, which do not split into two equal compartments", r.line, r.items, n)
+	}
+	var seen int
+	for i := range r.items {
+		if seen == n/2 {
+			return i, nil
 		}
-		for j := 0; j < len(c); j++ {
-			z[rune(c[j])] = struct{}{}
+		seen++
+	}
+	return len(r.items), nil
+}
+
+// split returns the sets of items in the two compartments of r.
+func (r rucksack) split() (ItemSet, ItemSet, error) {
+	mid, err := r.half()
+	if err != nil {
+		return 0, 0, err
+	}
+	a, err := r.set(0, mid)
+	if err != nil {
+		return 0, 0, err
+	}
+	b, err := r.set(mid, len(r.items))
+	if err != nil {
+		return 0, 0, err
+	}
+	return a, b, nil
+}
+
+// group splits rucksacks into groups of n, or returns an error if n is not
+// positive or the last group is incomplete.
+func group(rucksacks []rucksack, n int) ([][]rucksack, error) {
+	if n < 1 {
+		return nil, fmt.Errorf("group size %v must be positive", n)
+	}
+	if len(rucksacks)%n != 0 {
+		return nil, fmt.Errorf("group %v: %v rucksacks, want %v", len(rucksacks)/n+1, len(rucksacks)%n, n)
+	}
+	var groups [][]rucksack
+	for i := 0; i < len(rucksacks); i += n {
+		groups = append(groups, rucksacks[i:i+n])
+	}
+	return groups, nil
+}
+
+// compartments returns the sum of the priorities of the item found in both
+// compartments of each rucksack.
+func compartments(input string) (int, error) {
+	var total int
+	for _, r := range parse(input) {
+		a, b, err := r.split()
+		if err != nil {
+			return 0, err
 		}
-		for r := range x {
-			_, okY := y[r]
-			_, okZ := z[r]
-			if okY && okZ {
-				v, err := priority(r)
-				if err != nil {
-					return 0, err
-				}
-				total += v
+		total += a.Intersect(b).Priority()
+	}
+	return total, nil
+}
+
+// BadgesOf returns the sum of the priorities of the badge shared by each
+// group of n elves. The number of rucksacks must be a multiple of n.
+func BadgesOf(input string, n int) (int, error) {
+	groups, err := group(parse(input), n)
+	if err != nil {
+		return 0, err
+	}
+	var total int
+	for _, g := range groups {
+		common := allItems
+		for _, r := range g {
+			x, err := r.set(0, len(r.items))
+			if err != nil {
+				return 0, err
 			}
+			common = common.Intersect(x)
 		}
+		total += common.Priority()
 	}
 	return total, nil
 }
 
-func main() {
-	priority, err := run(`ZNNvFWHqLNPZHHqPTHHnTGBhrrpjvmwfMmpfpjBjwpmw
-	sbdzQgzgssgbglRtmjlwhjBlfrSrMt
-	zgsCRzJbsdRVQCDbcgLGWWLnZNGVLLZMNZnq
-	tvHhRtZGMvMHvfsrBBCTRbwbccRc
-	qznnlpzzDppWlDpQpCrcrwnBNwTZnBTZrn
-	PdVZJJqVZdllDPFtMjMgLjGMHvSgMF
-	csbhhVDDvzlVDcbccGGvfRjDHCjNLRHRCLfmnZfR
-	dFrStSTTmrrrHVfV
-	MMgQMMTMVTdgWtwTPwSgWSgGbbppJzlplvhBlPbzhlhbzG
-	FDJSTtSGhpPFDmFTZDpTFPmCBBrHqsCBhgBlqqrqrlRrHH
-	dQwMtfdzVwWfwctwnfnQCHllzRrsNzrrgNlCgqsr
-	fLfQnVjfwQfMdfvfnVvWDvtJPFGDpvZGbZpmbSPP
-	TzzCrJcDrTDdLDCJDvGNPCFqlZWlvNvWpq
-	RRHfjsQBFsjgjBQsWqGpNvZQqQlPPQPN
-	VnHBnRVssnnjsSfBwbMSrrbTwJTcwSDF
-	HJCgHCCFFFVGJWTlbqDdlqTDDpgl
-	cZccSmLrfZcrmmzSQftdpDtTHdbQTDMQ
-	NZZccrrBwZRPNNzmcLSSjJhGhVWCnsFnHBjGChsJ
-	qwwwJHTHqdFDtZBFPfFBZFzM
-	gVRcLnnWVgggnnnQgVWWNZtZrBfLBzZzBrMPPrZvPv
-	GQgQSVRtsVnNRGSCdpmwspmbmDpHmhwd
-	bhNgNfgwpbLMhCZMGQBmDm
-	FrcHrSllcqcFFMGLBDQlMDTGlT
-	FVSddRSJRjLwbjJPJw
-	wzhhrTwwTrSsdHQjjSHnBjQj
-	gRDCmVgRgMvtMfVMRBBBhWCHQQHGJHZJQZ
-	NtgVgttVbMNmvsNlpcrLhLTNPw
-	MCgjsfnscgjjgnGgJHHqHDgdHbGr
-	QSSmRFPpRtPFQLQRmPzvBzzzDWqrqWWHJGGNrJJbdtVWHJDV
-	BdSFdLQzRFlSLmQplffwncfscChhcsMj
-	GfVmfnmJVnNVFhnhGmbmhpHvqjrzHZBjfvrtBHHZrwBt
-	ddWQldlMdWMlQsLWTLQgMNwBrvjrZjNrwzZjswHqrv
-	QQdTRcgTRPDlMQlQPQdhcNNnbJmbGpVnGchFmm
-	CjjZCCZfvWZRHHhRtwhvPN
-	mrnqlqMqBlSSLnBTLBwmHPPWhPPHtFRPWzwt
-	rBVTrrMMSMLQBrndGcddWQbbdZfCZJ
-	LFtdjHjLjLqHqstLTjFLFqNMnMhhZdDDNMVbWdDDbhnZ
-	CrBpBGnzrzmczcllrphCZZWJMDWRbbZNMDMR
-	GwgvzpzvrcmBrnfHjTgqTsgHjF
-	rMPPZcplCZlZPwtSwhtBwCQQzB
-	FvDGffLqqmQFwmmhzt
-	TjJjJfHHVDVnHVgZZlQppcVscP
-	hVcqHwhgwwwjHjjGWbvrbBGrsWVWGn
-	CttPRpMmPDTWbWltlLBnGl
-	pZmDFMmPMfnZwqqwfcqJdHgz
-	bSJWhWJCbGGWJPStWTgRQwzDjgQQjsDW
-	nFBBVQVrVBrNFMFZVpBBZFZrDgdTldgsRsslsljsRzTRjzns
-	rMcZcHcBQPvbbHGP
-	mSfmwqfmzrfHwFfmrwvPHqPmMFRlMDDZBCVVRCVZVlZpMRRR
-	TWjdTWhTsssLTGsJNWhTQddjRMDMtNNBSCDBllMMBVtDMVRZ
-	QhWTQcdhjThsdGbTLGjWHmffnmHwnwHrwqmmfcwS
-	LmrsMQnnpfmMLllvTvqvFFzvFHNN
-	WGRFVWdwZWZvCbJzcvJNzw
-	VjGhDtWGSFRGjVVSFdjjDPBfspPnnMBLPLrrpMMm
-	qqqCCJjtqtqCtqLZspHWBdSrWWSzzbzHFWBldb
-	GhwwcwPFVDcNFRRGwwzmlBrBWvllrvSzlrcd
-	DGGhQNNDhTpZZqqLQFQQ
-	QfZmgQQZCCMLfNrgprdNvvdrTg
-	hhttsBmBDcFRBlJshJcRrnjnTvNqpddNNqvndp
-	JtsGJGtGGJJJHDbctllhZHmMwMQSPVPzHSLMPZmV
-	DScSjZcNBZqjDDcLLfFtPfCfjfPvfv
-	pTmRlWhdMwTLGwCf
-	mRdWCVVglWrCmVHVrVCmdrbSzNcBDBqBZDNHqssscNzqNc
-	sPMHGFMsrPNCPnNS
-	ffJzllbzpZBllttBtfglgBTbSCVCmmrNFmmbFNvCFLLb
-	cpZqpfgZZJtJqJJJfWHWhHdHWHjcdRdFHD
-	ZZPfppvzMrlNBFcvFB
-	shJgstJwWLVJwcrFFVFrBVNNqFFB
-	HwWJdLHWWLcQgssHwwSQSQtQzCnZZMpZCmdzZCzpPzpCPRCj
-	QCpLRbsCCQQLbQzCBQDQBBfTTffWtTctJVRNVtnfwtWV
-	GvlqqlGlmMrdsvrhmlcTvwJtwNwTvfJfcWTW
-	lMhgqGhddjqFFCzBBpbsSQpD
-	JJwGJwVQQwVSsSMhQMQgHfgfTtrrfVTNgNNfrt
-	dFDWCDdFppvDFmWWWnJTPllHmHlgrqrgggtH
-	DzFbWjdRpbdFCjjRbnFbQBGhhQBBJZwMhScwZwJz
-	HttvHpHmpJWtHmFNvlvdMSVdPMtLVCCMMMfcfL
-	GjgzhGSGSSdCcRMVjMdc
-	QshbnghgnGDnqsFrNSJFrsNs
-	wJpjMwzjzdVbzPPVpbCHnqGnBqnsBrNCwgrC
-	ftTLLDTQtLTGTGtFrgHrvqgQnrvQsCHH
-	fTcFFfLSfFFcGFllcFhPJPjWWJSjSWzMWPdS
-	ZjNdmjVQVZmvNNZNNZHWZmWtsJnwTpJJswpWwGqJhJqGpp
-	FcRRcDblDMLRcRMLFFMDGsJnqhwpqTTJGwnsfnlp
-	LRBrcLbbgLFgBbFqDvdHQvCCjNzzzVrZdV
-	BdbLWrgdvgWvVJgWnDfNhVnqhCCpDpcq
-	tSQPSTSGPMmlMPtQQPJGtGQRCcnqqfnRhCcChDqnCfRScf
-	jTssPsjMQMmszPjlTtsJdFBFrJzrbJdHZFHdWH
-	vCccctvvTTtZcgLGcZTbssbMWnpMpmLWqnNjpfPPfPjMPp
-	wwBBlRBBwDDVFRhFlRhdRRVWPnnpMpffmmffrpWqVNPm
-	ddhddRzHlQHFJcGsCztTgbNzST
-	fJctfpVWcnfRLfrRwP
-	vmmnvDQDZTNTmGGTqTMTvMqwBdLjBvRzBRrRBRLjjBPzBB
-	GMnmqSTFFQqttcbcJWgsSt
-	rHNfmfRsmfRGfDNcRmcmMQlLCGSnQwwPPCSnzQlSCl
-	bsJTBsVhFsVpqFWFgPCwnQwBZzwQzZLlzn
-	qggTTqvqgqbbTTFqVqgWqvNmmMMRdffftNfMDMmscR
-	rFWQFszrwjsjFWvshPTCmLZLSTLwSLlgSP
-	BQbcqVHNVqVpVpmClJgJJHSmZLJm
-	qBNNNVdDMGBpDcDWsvdQsFrFnjttfj
-	qGhmttmzhtMvhbrLdSHbdSHRzb
-	WCBgQJJpjCQlgdHZrfPRPSRbNg
-	jBTTDjlnjnJDJTQCVntcwtwMSvqcGFDhcvsh
-	ZTrnTqMWWWnfrddMGJPgPLlPbw
-	VvmGRVpBpNNmvNvjVjtpNpCNLLLJHHBdgLPdwsdsbLlwwlwb
-	GmCVSCRVGmpCRVvttmpDrQZfhnzhzqnDWnrZZTQq
-	DQBZHHtWHzSvZvDQWchgqsqqhrrhhcqrcZ
-	jdMfwlFfFlTfndwpjjwGnNrqhPTmPSPTPPhmgrPSrh
-	jlGbwGMdlnJpGFGjpnFCSJzzDDtWHCBBQBvtVC
-	RrbBWBRRWSRsBBVvsPHZDwSjjPdnHwtPtH
-	fTgfzMmNJpmJgfllgpjVQtDDndVQpdnHVtPp
-	gGmlNclTGmGFhLVcVrvLqrvc
-	QcpCTVCZVcCwLcCVvHvvVsCcNzNNSbPRzsDRDSBlsNNzDRtb
-	fggMfJqgrWFpmjWMggmrfMWNSbRSPBDbNtJRtPJzlStBbN
-	gdnmpWGnZvdQCvdv
-	tqqcLqqDDqNtDrqHrrPWlTlTWZTMzTFzQlMPSZ
-	pfnpmmppmppRGjwbjmnjwspWbQQQTMWZbCTSZCSQlCllZF
-	gmpVnGmmmpjDvVLBFqqvrH
-	LqBvJHZvbHGBHrBtGGQTmSVprVzhpVPDPQzQ
-	CRdRgwCfhTVDzSdQ
-	fRCcjgSMjfNgMMLGbGZtvBbGHv
-	HgvtDDzDpvwgvvqdHPZWdMssTTddSs
-	rJFrGNFVQmNFVmRnWhhsrTbhwhZTrdTd
-	VQGBBBVNQClpcBvBwD
-	PWlSzZGmdmGmlGmhggBpvMjvMjFgPJ
-	TtLRDtQQfTVcQQQRtBsJFFccFjWhJJFMBs
-	HqVCNtWHCDwdnlGwGqSr
-	RwdRJgCJRGGmdMbcGbdnTnTtttLLnptMtMtMqZ
-	DWsWPFrPqVPPLVCB
-	zQWWsslsQHFhDSszDSFQzJJJmvcgblRgmNvCJmvNgw
-	tpmFrWTtRpRTtggsSlnQpsnnlSHPsn
-	bZwZjNNZGLSrVsGndPPV
-	NvrcjCfbvvLBDBWfWFgRRm
-	WWFMgWmMhhwDcMMMDcmLWLtQwwsjbsQHvZHbRjZfsZzH
-	PTCplTCdSJJCpvPGNSvsbsfHtbQZzdHjQtjjsj
-	vNGJPpqJvJvqghgFgWFmLD
-	RlRpLTZCjWRjRWwpRsjHjbSbqMqMvvnbnGMnGGqQCq
-	gddfDNczmgPthNcDdgPVnbbzbnJrJJGSSVJJQS
-	BmDmcDmcmhffdBHlRwjRLpwlWQ
-	prQlfzlWRPzgQWzlMPMRppssHHsDsHjwnHHbWDwwbwjL
-	vFBJJtZNShJvZFtdSqtmqjTDVHVGDHbwVHDVsDnThH
-	vcjBZZdZqvCfpzRfcgRp
-	cggpqgRlSpNsgNggbjjj
-	ZZSSJVLVLFDZWNGjCWWbCjsF
-	vZLvfZQQfQtJVJDQShLrLfMmnldmwqwTqqMcMTMTndrm
-	bQBMtBPddtMFbJFhRGzMfzvnRGRSvWnW
-	TmHTqlVHwVpQqjmwGvSgSpnLpzfWGWSn
-	TTrDQCDrrTmDCCCVHHQZBdZFPdsNdFBtFDhtFB
-	fjpQvNZcGhGGTtQS
-	DVJzvbVmHbbtSTSTRStzTM
-	VDvmqllmJfjWlnplNs
-	ZmdHZJjvQLdRjpmLJrqqZBhhtCschPfBPcrDfPffCD
-	MWWSMMwnwlSgzWFFgSwzVwzqcfDCfChCbbtssbfDChcD
-	NMqFTwGqMwgwwgjHRdHRjdmQmQTm
-	TTqWPCWRhTWqPNjPJMNtrlbJFttQwwrBrlbwlc
-	GfpSDGZvpQffSHDgggDZrHctFmrHncnnwwbBtBrt
-	SQGfLsSLZsqMTRNMPT
-	HdBdnBZJTZBBmsfwwBlh
-	MjCVjzwqWrfzplzW
-	vVbqCjjRgjwMbnbGHJScScZHLL
-	dwwwtCdznvDDFrMrrw
-	GmWLQmgQmHgcdGcsTgTDqDbSfFWfMDMfbSNqvr
-	QhTLmVQHLmdLTjGGVptRnZpZBZVRpPpP
-	CzjFpzRHdtBFBCqNqSbJZWcQJTSbQjMTWZ
-	wGwVLlGrdVGwDnwsgfMSZvJMbWJcWlvbbMSc
-	rDfsgggrGnGngsPwdVLfDnmDtzzFNCPHtzCtFHpBRqhPztzR
-	mrgWzBcDtVCcQcCCdscf
-	LRJhjRjPZvqSRGhGjLgMCdHpMNwQCpMHpHMS
-	GRvGJRJjqPZbvGGhRjnqLJWtgFgtzTzDrFnTWrlTlllW
-	cbmcddlffvbTfvFflpZzsMVNznNVlnqnzqHMNM
-	StWJBQRWLRWNPNMCswRVHC
-	BJQBhSWhjSthJQGGWWggJDDDfbdbbfHbddbrFrddvFvv
-	jFqvqvWZWDtBJrrlrq
-	TzGcbHcrmVzMGNSmTcGDtBthJCNtsJDlBCghgP
-	bTrnTccnLSrrTHbnwfLjfdvRRwZFdwfR
-	drHVrdVDfsDbVsdVDbVqRwbZZwCRCCCJlJThwRgT
-	jFPcFpBSvtNPzSFcjcQpcQjpThZCRltGRRRJhwCwGhwgwhRm
-	SQSzPBjjPPSvLqqssdnqLZLMsM
-	bQTWlWlvQclNwwWlCCLStCRSSjStpj
-	zVZZDdBnBmgzVsjsLthSpshdCL
-	DfBnrmBmgzHBfDHmnGrNFCwQvTPvqCTwqTFGbF
-	srSWJnrbmlWlbhzsWszSvPGwvgDhcjdjjfvhjvGv
-	BRRQFLtNfQNMpqpQHDjdDjDcZZcvwZZHPH
-	NLCNCtRQfRttRFRCTqMBqQQrzrbzrlJmVVbsSWmVrTbSzJ
-	RHLfLcSRTFSghLRHGbwZmMZddgJswZsbMm
-	ptqjtCzzQztqCjDlBGpDpbMZdwmMbZsdwNmdJpbs
-	tttzCVllDCtDQnQBVHGHWvWTLWcLSLHf
-	FVlNnPqbGTHftghggJqf
-	zLcZWZpWWrcrZLLZDWrwMcrhBFBttChBmBgptChhtFftmf
-	LZZLrDrrDDMrcwrDwsWFzdTlnGQPQQVbdbnsvnvsVQ
-	BbPNMJNbQvDbvPLwHflczlwwzf
-	pZjWZGZjFGdgpnVgZhghdmcflrlswzzcstlrLwhtwc
-	WZSdqFjqSqSWdGFjZpdMTTDNTvLCRRLLqRQMCN
-	FqgFGtbgTvRwrLqhvw
-	JCCWJWCdJMQNNsSWsMPQRDDLDSDLwTrrvnwfDvnD
-	HdPJlBBHCCQdBMWdTtVbgHczGVGjmtzG
-	PLlZDLZDsFCvbDQv
-	HVcTmVmJqVzqczfzbjvvCFMRfCsWjMvR
-	cqHzTqJTTTTzzmnmrctrBlLlvSlgLdZvSwSlpw
-	SbMMNJjmgMnJdSSbjVFZVSQrlQfWVQVWZh
-	PtqDqPGcLHzHpqLcRzRsfQFfZlfRfZfRFVsl
-	cTDLcqGCzDTqzzDLDzqPTtJvbBJMnmvjbdlmJNvmdgNC
-	tDJDlZVqJGbvHNQbNFFsFPmLns
-	ppczpzpffGwfBNLGmn
-	WShzgTTpWzhWztJJGJSvtvvtjq
-	TbZFTFScnCZFQRTCqQdBjdJqjBqjjQDB
-	rmmLpLLfzrlmslMBHvdRddNDDJDrqD
-	MWwLPzmWfpsMmmlMPMWLwRTZTZnnTcVCcZFCwSnZ
-	SqmClqHssNWCqPTcWcGhBTchVV
-	ZnnnDflRpBVTTVhPBZ
-	DpgfvnvMfCsqlMtSll
-	ZzLMRZpLMwwppZqnQGvQgBSvlNVlBFFNFVrg
-	HcqhTmhmdDTPFTJgTTFBSgJN
-	mccPdDDHbssbtwZMqpbzCRGM
-	TgqnTltgWqLRSRnlqddngFfrvHvrBTfCCFrFVTvVCf
-	cwNJmPzQwNzczzNsJGhhHfhrfvVHGvtvVVfC
-	jjtbtDswcmPWlbgRnRdMZL
-	TmpTBBwvspTptRmsmTGLQDGRHGgVGLSQSMHQ
-	ZlPWqjWrzjPqdrlzbrbrwfrWLHVMLnHDMVDQnLQfQfVngQLS
-	zNwbrrFWbFJpmpmvvt
-	RMQQMwHMMzcFsWsDrWfcpJpS
-	LLhZmGVLhVlTZfWWfWpCrDsGSp
-	VLVTnqjjZngtQRFjvzDM
-	gmRBpjrpRvCfRCrBgvjHShnbnngbgSJnNsHMHS
-	ZDPTwGWtqwHhSnbcMNJw
-	DWGGqtVVqldWZzMzWmvjrjprLRFjRVvvff
-	tCzVzsVtDFzssnSsgdqJdCNqJhmgmpqq
-	PZccPGvQfRLMQwNdhpwhNh
-	jLrcbRjPZBrcPdjRHFlWnVtBFslSWznW
-	vvvbJbWrLvFWHzZzZRhB
-	chtwTmCNlRRZzRPT
-	hmcCssCswrMDGMSrsr
-	LStGBsQLlllhzMzs
-	dzVZDNWRDdZNDTZTPvWVhhphpMlfMccRmfnlMlRn
-	VFvgTrNPdFWNNFNFTzTFFSjSQBCqrtQwSBGLLBGwGL
-	qGJSJhWStdSfWvSvtGRRnzRDDggrgvnzsmRP
-	lTTLpcljjGlLlLNBpjwFQDQmRnrRDPrPscRrDDng
-	NCNjFlHNCTVjpwGqGSVbJddqZZJM
-	MbWdgvHFlMvmzTzShvmm
-	tqjqpLsNsrrsjstNLpQrGVhVBzrhVcfmchDcTPVVmc
-	RqwjqjqsGjjGGQNjGpQZpqRFJgmMHwdbFWgnHMFdwmmCFW
-	HHHLcCcVHjTHglsB
-	wDSRwzzRpMSdNSPSwSpRbqvgBsdqlgTvBFBjgFvvgB
-	RpbzPssDMWwNRbRNRPDsDhJthLQVGLJcctQCJQfQJCLm
-	WsZgbNgZVCCWbVVVmgZbCCRPccGnzPBqJjzWJBJPzvBvGz
-	SpfThHtrHFBPPzJvPntj
-	QHDhhrhpTQpHhQHnfwnTCNlbZCCDLNllZlVsNCNl
-	QtzJFRQLMRnZcZsfcphlPQ
-	qSBbjmWSCNmVldSqqSqmjCSZshfwfrPPZZfcPVZfhgsgPg
-	HqBbHqBGSlNBbltnLLHFJMtRvRTD
-	tcGtDdMcttttHNBlMctldlwjwwqqCLCwDwZjFCZhmnwC
-	VrJgvWWsPvRgVgrJQvfQfzgVzZwCbLZmnmwCwZqmnhjZbnLj
-	sJpffsRWWRJVWWpHltSpnMHGcMTl
-	zNqRbqSbfdcTLLfS
-	ZVPzPnVvdLwLDPfF
-	VWnzQCVWZVMzQRHgqgqrHGtGMp
-	PbHpWfWPvRfbzWPFfRpPDtBwSHMwCBgDwBjDtMMM
-	hTTdZQlcnTcmqVTdcddrDgBSwsjjBgqBtsCgMD
-	hlldTmdJJmJdZvzfFfNJFJgRzR
-	PJWvJBbWsfLQWsLvmCqHCcNLHqHLLcwDqV
-	dQztrZrdwHhptqDH
-	ZrMGjgMSrdzQGQRJPvGGbm
-	RmjljZChlDZBCRRvlmNSLSqMNLzwLvppwQSQ
-	sTnVnPrVGsGTPddJrfgQgqLgGpMNQtgNtNzg
-	sbbTfTdcJPnHbsJfHsdcmDDmmqBZlClmjBRDCZ
-	CJmHLmHFFCFbHsbJsJqvqhQqLDhQZvnQDZnn
-	wGwppTjdWPdgFpGcScBqNnNqNhQlDqnDlZZW
-	pGcgGgTpGjFdwpSFVgSdpPjrMCMffzJzRzztRfHCRsVmtbsz
-	CgBClZfCflPflNZRvfQswwmwmwQsQhgppdhm
-	qbzDGrjLLNLDHDqtJmmhhmQdhwpQhhbp
-	NLGqVqjDjjGrMFrvFWPBRBZnCvfFnT
-	tbrrHsgsVmmmbtgwVsQRqjJMmqMjQfJfLFLD
-	ZvlBGzdvjGfRFJQJ
-	dBppnnBBhdzZncBPlznpnNdWHSsbWthbSCgHrVfgSSwVgr
-	VRvMtRVFHQLvMRQFQtBctrthshTTgCmhTrgWhWZsZZ
-	lzJlGBSPPhzjgZsTCr
-	wJlpJPfDSpwBnddqJDdpPpcvMFHFMvNbvnNMFHHRVVbR
-	CPShbbdlGCdQqlRPGPdlDWDFzjtFjggCDJgWczfF
-	mrHrTrrBMBsmNsrwsBpnfpggDDcjjDDpjzFJzzjtJz
-	BvsNvBLHrrrNvwBTNNsNGbdQhlPGGfqhhRGqLGdl
-	PSSlPtlStGhPNMtwPMPJzDddnbnDNTDDnJqjbz
-	FFVHRwVLvFvVrVHrZcLmRHggjDmdDnDnznnznzQjzdmJddbn
-	WrvgRgcRcRrrcRvgcVrHVrwCCSfsCsGsllhMSSSSMttlSCpG
-	hBPJqVZTqqPSlGlfddfddZvl
-	JWWMJCpnMrmztzdjnzld
-	RbWsrwMrpbRspbWgpwhLJPccNVqLLPSVgVPV
-	hcTrWqcfhwGfWrWMjHjGvDHPmJMDzF
-	ZtlsnZZtLBSbSssnbndjDJJFHFHJPHPsHMTHHM
-	ntRZtSbtZgZStTqchwQfRwNpcq
-	GfLqrsqQGgPgjjQGVcNvTpTpNFcWPvPPpT
-	bRnRLnMZFdCMcpvT
-	RnRhzRlmlhhHhhmhRsqLrfzrGVSrGBSGrL
-	fbMffwdZsncrGcfG
-	qDBjSSLqhLBSmDbjqNhqTLjCGrCHGrvcGWcpWcrGWnCrpm
-	STLDqbhTLqNTNSRhlwZlJlRQFFRwMdPQ
-	TVVGNFggcjPPJzwvQlRRwRvSlcSc
-	frsBbWhtSRzSLfRf
-	qDCqddbsWrqzhsdNmdJNJHjTggFFVV
-	NTWTDrSdFTLtPTGf
-	lZqjHlVRvRltLtRWFMtFLL
-	qvjWzzvVbZpjqllggscdchwDrCphwsdhrD`)
-	if err != nil {
-		log.Fatal(err)
+// Mode selects which items of the rucksacks are summed.
+type Mode int
+
+const (
+	// Compartments sums the item found in both halves of each rucksack.
+	Compartments Mode = iota + 1
+	// Badges sums the item shared by each group of three rucksacks; see
+	// BadgesOf for other group sizes.
+	Badges
+)
+
+var modes = map[string]Mode{
+	"compartments": Compartments,
+	"badges":       Badges,
+}
+
+// ParseMode returns the mode with the given name, "compartments" or
+// "badges".
+func ParseMode(name string) (Mode, error) {
+	m, ok := modes[name]
+	if !ok {
+		return 0, fmt.Errorf("mode %q unrecognized", name)
 	}
-	fmt.Println(priority)
+	return m, nil
+}
+
+func (m Mode) String() string {
+	for name, n := range modes {
+		if m == n {
+			return name
+		}
+	}
+	return fmt.Sprintf("Mode(%d)", int(m))
+}
+
+// Sum returns the sum of the priorities of the items selected by m.
+func Sum(input string, m Mode) (int, error) {
+	switch m {
+	case Compartments:
+		return compartments(input)
+	case Badges:
+		return BadgesOf(input, 3)
+	}
+	return 0, fmt.Errorf("mode %v unrecognized", m)
+}
+
+// Part1 returns the sum of the priorities of the item found in both
+// compartments of each rucksack.
+func Part1(input string) (int, error) {
+	return Sum(input, Compartments)
+}
+
+// Part2 returns the sum of the priorities of the badge shared by each group of
+// three elves.
+func Part2(input string) (int, error) {
+	return Sum(input, Badges)
 }
 
 func init() {
-	log.SetFlags(log.Flags() | log.Lshortfile)
+	registry.Register(3, 1, registry.Int(Part1))
+	registry.Register(3, 2, registry.Int(Part2))
 }
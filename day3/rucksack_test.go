@@ -0,0 +1,261 @@
+package day3
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"advent2022/bench"
+	"advent2022/input"
+	"advent2022/registry"
+)
+
+func TestCompartments(t *testing.T) {
+	if got, err := Sum("vJrwpWtwJgWrhcsFMMfFFhFp", Compartments); err != nil || got != 16 {
+		t.Errorf("Sum(Compartments) = %v, %v, want 16", got, err)
+	}
+	want := `line 2: "abc" has 3 items, which do not split into two equal compartments`
+	if _, err := Sum("aa\nabc", Compartments); err == nil || err.Error() != want {
+		t.Errorf("Sum(Compartments) error = %v, want %v", err, want)
+	}
+}
+
+func TestBadgesOf(t *testing.T) {
+	tests := []struct {
+		input string
+		n     int
+		want  int
+		err   string
+	}{
+		{"ab\nbc\nbd", 3, 2, ""},
+		{"ab\nbc\nbd\nXy\nXz\nXw", 3, 2 + 50, ""},
+		{"ab\nbc\nda\nca", 2, 2 + 1, ""},
+		{"ab", 1, 3, ""},
+		{"ab\nbc\nbd\nXy", 3, 0, "group 2: 1 rucksacks, want 3"},
+		{"ab\nbc", 3, 0, "group 1: 2 rucksacks, want 3"},
+		{"ab", 0, 0, "group size 0 must be positive"},
+	}
+	for _, tt := range tests {
+		got, err := BadgesOf(tt.input, tt.n)
+		if tt.err != "" {
+			if err == nil || err.Error() != tt.err {
+				t.Errorf("BadgesOf(%q, %v) error = %v, want %v", tt.input, tt.n, err, tt.err)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("BadgesOf(%q, %v) = %v, %v, want %v", tt.input, tt.n, got, err, tt.want)
+		}
+	}
+}
+
+func TestPriority(t *testing.T) {
+	for r, want := range map[rune]int{'a': 1, 'z': 26, 'A': 27, 'Z': 52} {
+		if got, err := priority(r); err != nil || got != want {
+			t.Errorf("priority(%q) = %v, %v, want %v", r, got, err, want)
+		}
+	}
+	for _, r := range "É1 é[`" {
+		if got, err := priority(r); err == nil {
+			t.Errorf("priority(%q) = %v, want an error", r, got)
+		}
+	}
+}
+
+func TestInvalidItems(t *testing.T) {
+	tests := []struct {
+		input string
+		m     Mode
+		want  string
+	}{
+		{"abab\naÉaÉ", Compartments, `line 2, column 2: invalid item 'É'`},
+		{"abab\r\n\r\nab1b", Compartments, `line 3, column 3: invalid item '1'`},
+		{"ÉÉ", Compartments, `line 1, column 1: invalid item 'É'`},
+		{"aÉÉ", Compartments, `line 1: "aÉÉ" has 3 items, which do not split into two equal compartments`},
+		{"ab\nbc\nbdéx", Badges, `line 3, column 3: invalid item 'é'`},
+		{"  aÉaÉ", Compartments, `line 1, column 4: invalid item 'É'`},
+		{"ab\nbc\n\tbdéx", Badges, `line 3, column 4: invalid item 'é'`},
+		{"ab\nbc\n\u00a0 é", Badges, `line 3, column 3: invalid item 'é'`},
+	}
+	for _, tt := range tests {
+		_, err := Sum(tt.input, tt.m)
+		if err == nil || err.Error() != tt.want {
+			t.Errorf("Sum(%q, %v) error = %v, want %v", tt.input, tt.m, err, tt.want)
+		}
+	}
+}
+
+func TestItemSet(t *testing.T) {
+	var s ItemSet
+	for _, r := range "abcZa" {
+		if err := s.Add(r); err != nil {
+			t.Fatalf("Add(%q) = %v", r, err)
+		}
+	}
+	if got, want := s.Items(), []rune("abcZ"); !reflect.DeepEqual(got, want) {
+		t.Errorf("Items() = %q, want %q", got, want)
+	}
+	if got, want := s.Len(), 4; got != want {
+		t.Errorf("Len() = %v, want %v", got, want)
+	}
+	if got, want := s.Priority(), 1+2+3+52; got != want {
+		t.Errorf("Priority() = %v, want %v", got, want)
+	}
+	if !s.Contains('Z') || s.Contains('A') || s.Contains('1') {
+		t.Errorf("Contains reports the wrong members of %q", s.Items())
+	}
+	var u ItemSet
+	u.Add('c')
+	u.Add('A')
+	if got, want := s.Intersect(u).Items(), []rune("c"); !reflect.DeepEqual(got, want) {
+		t.Errorf("Intersect() = %q, want %q", got, want)
+	}
+	if got, want := s.Union(u).Items(), []rune("abcAZ"); !reflect.DeepEqual(got, want) {
+		t.Errorf("Union() = %q, want %q", got, want)
+	}
+	if got := allItems.Len(); got != maxPriority {
+		t.Errorf("allItems.Len() = %v, want %v", got, maxPriority)
+	}
+	if err := s.Add('1'); err == nil {
+		t.Errorf("Add('1') succeeded")
+	}
+}
+
+func TestReport(t *testing.T) {
+	in, err := input.ReadFile("testdata/sample.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	groups, err := Report(in, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(groups) != 2 {
+		t.Fatalf("Report() returned %v groups, want 2", len(groups))
+	}
+	var badges, shared []rune
+	var total int
+	for _, g := range groups {
+		b, ok := g.Badge()
+		if !ok {
+			t.Errorf("group with common items %q has no badge", g.Common.Items())
+		}
+		badges = append(badges, b)
+		total += g.Priority()
+		for _, r := range g.Rucksacks {
+			shared = append(shared, r.Shared.Items()...)
+		}
+	}
+	if got, want := string(badges), "rZ"; got != want {
+		t.Errorf("badges = %q, want %q", got, want)
+	}
+	if got, want := string(shared), "pLPvts"; got != want {
+		t.Errorf("shared items = %q, want %q", got, want)
+	}
+	if total != 70 {
+		t.Errorf("total priority = %v, want 70", total)
+	}
+	if got := groups[1].Rucksacks[0].Line; got != 4 {
+		t.Errorf("line of the fourth rucksack = %v, want 4", got)
+	}
+
+	groups, err = Report("abab\ncdcd\nabcd\nabcd", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := groups[0].Badge(); ok || groups[0].Common.Len() != 0 {
+		t.Errorf("group 1 has common items %q, want none", groups[0].Common.Items())
+	}
+	if _, ok := groups[1].Badge(); ok || groups[1].Priority() != 1+2+3+4 {
+		t.Errorf("group 2 has common items %q, want \"abcd\"", groups[1].Common.Items())
+	}
+	groups, err = Report("ab\nabc\nbd", 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b, ok := groups[0].Badge(); !ok || b != 'b' {
+		t.Errorf("badge of a group with an odd rucksack = %q, %v, want 'b'", b, ok)
+	}
+	if r := groups[0].Rucksacks[1]; r.Split || r.Shared != 0 {
+		t.Errorf("odd rucksack = %+v, want it unsplit", r)
+	}
+	if r := groups[0].Rucksacks[0]; !r.Split {
+		t.Errorf("even rucksack = %+v, want it split", r)
+	}
+	if _, err := Report("ab\nab", 3); err == nil {
+		t.Errorf("Report() of an incomplete group succeeded")
+	}
+}
+
+// mapBadges is badges using maps for the item sets, kept as a baseline for
+// BenchmarkItemSet.
+func mapBadges(lines []string) int {
+	var total int
+	for i := 0; i+3 <= len(lines); i += 3 {
+		sets := make([]map[rune]struct{}, 3)
+		for j := range sets {
+			sets[j] = map[rune]struct{}{}
+			for _, r := range lines[i+j] {
+				sets[j][r] = struct{}{}
+			}
+		}
+		for r := range sets[0] {
+			_, y := sets[1][r]
+			_, z := sets[2][r]
+			if y && z {
+				p, _ := priority(r)
+				total += p
+			}
+		}
+	}
+	return total
+}
+
+// setBadges is mapBadges using ItemSet.
+func setBadges(lines []string) int {
+	var total int
+	for i := 0; i+3 <= len(lines); i += 3 {
+		common := allItems
+		for _, line := range lines[i : i+3] {
+			s, _ := rucksack{items: line}.set(0, len(line))
+			common = common.Intersect(s)
+		}
+		total += common.Priority()
+	}
+	return total
+}
+
+func benchLines(b *testing.B) []string {
+	in, err := input.ReadFile("testdata/input.txt")
+	if err != nil {
+		b.Fatal(err)
+	}
+	return strings.Fields(in)
+}
+
+func BenchmarkItemSet(b *testing.B) {
+	lines := benchLines(b)
+	if got, want := setBadges(lines), mapBadges(lines); got != want {
+		b.Fatalf("setBadges = %v, want %v", got, want)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		setBadges(lines)
+	}
+}
+
+func BenchmarkMap(b *testing.B) {
+	lines := benchLines(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		mapBadges(lines)
+	}
+}
+
+func BenchmarkPart1(b *testing.B) {
+	bench.File(b, registry.Int(Part1), "testdata/input.txt")
+}
+
+func BenchmarkPart2(b *testing.B) {
+	bench.File(b, registry.Int(Part2), "testdata/input.txt")
+}
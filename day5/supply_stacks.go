@@ -1,10 +1,11 @@
-package main
+package day5
 
 import (
 	"fmt"
-	"log"
 	"strconv"
 	"strings"
+
+	"advent2022/registry"
 )
 
 type move struct {
@@ -81,6 +82,8 @@ func trimNumber(s string) (string, int, error) {
 	return s, n, nil
 }
 
+// applyMoves moves the crates with a CrateMover 9001, which lifts several
+// crates at once and so keeps their order.
 func applyMoves(moves []move, crates [][]string) ([][]string, error) {
 	for _, m := range moves {
 		for i := 0; i < m.numCrates; i++ {
@@ -92,549 +95,80 @@ func applyMoves(moves []move, crates [][]string) ([][]string, error) {
 	return crates, nil
 }
 
-func run(input string) error {
+// applySingleMoves moves the crates with a CrateMover 9000, which lifts one
+// crate at a time and so reverses their order.
+func applySingleMoves(moves []move, crates [][]string) ([][]string, error) {
+	for _, m := range moves {
+		for i := 0; i < m.numCrates; i++ {
+			j := len(crates[m.from]) - 1 - i
+			crates[m.to] = append(crates[m.to], crates[m.from][j])
+		}
+		crates[m.from] = crates[m.from][:len(crates[m.from])-m.numCrates]
+	}
+	return crates, nil
+}
+
+func parse(input string) ([]move, [][]string, error) {
 	before, after, found := strings.Cut(input, "\n\n")
 	if !found {
-		return fmt.Errorf("strings.Cut(%q, %q): not found", input, "\n\n")
+		return nil, nil, fmt.Errorf("strings.Cut(%q, %q): not found", input, "\n\n")
 	}
 	moves, err := parseMoves(after)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 	crates, err := parseCrates(before)
 	if err != nil {
-		return fmt.Errorf("parse crates: %v", err)
+		return nil, nil, fmt.Errorf("parse crates: %v", err)
 	}
-	crates, err = applyMoves(moves, crates)
+	return moves, crates, nil
+}
+
+// Part1 rearranges the crates with a CrateMover 9000 and returns the crate on
+// top of each stack.
+func Part1(input string) (string, error) {
+	moves, crates, err := parse(input)
 	if err != nil {
-		return err
+		return "", err
 	}
-	for _, c := range crates {
-		log.Printf("%#v", c)
+	crates, err = applySingleMoves(moves, crates)
+	if err != nil {
+		return "", err
 	}
-	return nil
+	return tops(crates)
 }
 
-func main() {
-	err := run(`    [B]             [B] [S]        
-    [M]             [P] [L] [B] [J]
-    [D]     [R]     [V] [D] [Q] [D]
-    [T] [R] [Z]     [H] [H] [G] [C]
-    [P] [W] [J] [B] [J] [F] [J] [S]
-[N] [S] [Z] [V] [M] [N] [Z] [F] [M]
-[W] [Z] [H] [D] [H] [G] [Q] [S] [W]
-[B] [L] [Q] [W] [S] [L] [J] [W] [Z]
- 1   2   3   4   5   6   7   8   9 
-
-move 3 from 5 to 2
-move 5 from 3 to 1
-move 4 from 4 to 9
-move 6 from 1 to 4
-move 6 from 8 to 7
-move 5 from 2 to 7
-move 1 from 5 to 4
-move 11 from 9 to 7
-move 1 from 1 to 9
-move 6 from 4 to 6
-move 12 from 6 to 7
-move 1 from 9 to 2
-move 2 from 4 to 6
-move 1 from 8 to 9
-move 1 from 9 to 4
-move 1 from 6 to 1
-move 2 from 7 to 5
-move 2 from 6 to 7
-move 2 from 1 to 6
-move 2 from 4 to 7
-move 1 from 5 to 4
-move 1 from 5 to 6
-move 1 from 6 to 1
-move 1 from 1 to 3
-move 1 from 4 to 1
-move 1 from 1 to 4
-move 1 from 4 to 5
-move 1 from 3 to 9
-move 1 from 5 to 1
-move 4 from 2 to 1
-move 20 from 7 to 8
-move 24 from 7 to 3
-move 3 from 6 to 4
-move 1 from 1 to 9
-move 1 from 9 to 3
-move 2 from 1 to 2
-move 2 from 4 to 1
-move 2 from 2 to 1
-move 14 from 3 to 6
-move 6 from 1 to 6
-move 10 from 3 to 2
-move 1 from 2 to 3
-move 6 from 6 to 5
-move 2 from 3 to 4
-move 13 from 8 to 4
-move 1 from 9 to 7
-move 1 from 6 to 3
-move 10 from 4 to 2
-move 1 from 3 to 6
-move 2 from 8 to 7
-move 1 from 7 to 2
-move 11 from 6 to 8
-move 2 from 6 to 1
-move 2 from 1 to 3
-move 1 from 8 to 6
-move 1 from 3 to 9
-move 3 from 8 to 2
-move 1 from 3 to 6
-move 2 from 6 to 4
-move 1 from 6 to 5
-move 11 from 2 to 9
-move 2 from 4 to 6
-move 1 from 6 to 1
-move 1 from 1 to 5
-move 11 from 2 to 7
-move 12 from 7 to 5
-move 1 from 6 to 2
-move 10 from 8 to 7
-move 6 from 5 to 3
-move 4 from 5 to 4
-move 11 from 9 to 7
-move 7 from 4 to 9
-move 4 from 9 to 6
-move 12 from 7 to 3
-move 1 from 8 to 9
-move 1 from 5 to 1
-move 1 from 1 to 2
-move 1 from 6 to 9
-move 3 from 4 to 1
-move 1 from 9 to 7
-move 8 from 7 to 2
-move 3 from 6 to 1
-move 8 from 2 to 3
-move 1 from 7 to 4
-move 2 from 7 to 2
-move 1 from 5 to 2
-move 8 from 5 to 1
-move 3 from 9 to 6
-move 1 from 6 to 2
-move 1 from 4 to 5
-move 1 from 5 to 4
-move 2 from 9 to 3
-move 1 from 8 to 6
-move 1 from 4 to 5
-move 1 from 5 to 1
-move 1 from 6 to 8
-move 1 from 8 to 1
-move 7 from 1 to 5
-move 11 from 3 to 7
-move 1 from 1 to 9
-move 4 from 2 to 1
-move 5 from 1 to 3
-move 1 from 5 to 9
-move 1 from 6 to 3
-move 6 from 2 to 1
-move 5 from 7 to 3
-move 1 from 6 to 8
-move 1 from 8 to 4
-move 6 from 7 to 9
-move 4 from 9 to 8
-move 2 from 8 to 9
-move 2 from 5 to 8
-move 13 from 3 to 7
-move 1 from 3 to 8
-move 2 from 1 to 9
-move 3 from 1 to 5
-move 1 from 4 to 1
-move 6 from 5 to 9
-move 8 from 9 to 8
-move 2 from 7 to 3
-move 1 from 9 to 7
-move 1 from 5 to 2
-move 5 from 9 to 8
-move 1 from 8 to 7
-move 1 from 2 to 9
-move 7 from 1 to 2
-move 4 from 7 to 5
-move 6 from 2 to 3
-move 1 from 2 to 1
-move 10 from 8 to 9
-move 3 from 8 to 9
-move 4 from 5 to 1
-move 2 from 8 to 6
-move 9 from 9 to 8
-move 1 from 9 to 6
-move 8 from 8 to 4
-move 12 from 3 to 5
-move 1 from 4 to 2
-move 3 from 8 to 1
-move 3 from 9 to 7
-move 1 from 3 to 2
-move 1 from 6 to 9
-move 8 from 3 to 8
-move 6 from 4 to 5
-move 1 from 7 to 6
-move 1 from 8 to 1
-move 6 from 8 to 7
-move 1 from 3 to 6
-move 7 from 1 to 5
-move 1 from 4 to 9
-move 4 from 6 to 5
-move 13 from 7 to 5
-move 1 from 8 to 2
-move 2 from 9 to 3
-move 4 from 7 to 2
-move 1 from 3 to 8
-move 1 from 3 to 4
-move 4 from 1 to 2
-move 1 from 5 to 7
-move 23 from 5 to 6
-move 1 from 8 to 6
-move 1 from 9 to 4
-move 5 from 2 to 6
-move 1 from 4 to 9
-move 1 from 9 to 3
-move 1 from 7 to 8
-move 1 from 4 to 3
-move 1 from 3 to 7
-move 1 from 7 to 5
-move 1 from 8 to 7
-move 12 from 6 to 1
-move 1 from 2 to 5
-move 1 from 3 to 1
-move 20 from 5 to 2
-move 14 from 2 to 4
-move 11 from 2 to 6
-move 1 from 7 to 8
-move 13 from 1 to 8
-move 9 from 8 to 4
-move 3 from 8 to 6
-move 10 from 6 to 8
-move 6 from 6 to 4
-move 4 from 8 to 5
-move 26 from 4 to 2
-move 2 from 5 to 2
-move 5 from 8 to 1
-move 1 from 8 to 3
-move 2 from 1 to 3
-move 2 from 3 to 7
-move 27 from 2 to 7
-move 2 from 8 to 1
-move 1 from 3 to 7
-move 6 from 6 to 2
-move 4 from 6 to 1
-move 4 from 6 to 4
-move 2 from 5 to 4
-move 4 from 2 to 1
-move 3 from 1 to 8
-move 1 from 2 to 8
-move 8 from 4 to 3
-move 1 from 2 to 8
-move 5 from 8 to 6
-move 1 from 4 to 2
-move 1 from 2 to 1
-move 6 from 3 to 1
-move 13 from 7 to 1
-move 1 from 2 to 8
-move 1 from 8 to 2
-move 1 from 6 to 2
-move 1 from 2 to 8
-move 1 from 8 to 2
-move 14 from 7 to 1
-move 5 from 6 to 3
-move 2 from 3 to 1
-move 3 from 3 to 2
-move 3 from 7 to 4
-move 1 from 4 to 9
-move 1 from 9 to 7
-move 2 from 3 to 6
-move 5 from 2 to 7
-move 1 from 7 to 6
-move 5 from 7 to 6
-move 2 from 6 to 7
-move 1 from 6 to 8
-move 1 from 4 to 7
-move 4 from 6 to 9
-move 35 from 1 to 8
-move 3 from 7 to 2
-move 1 from 2 to 5
-move 24 from 8 to 3
-move 1 from 5 to 8
-move 13 from 3 to 6
-move 2 from 2 to 6
-move 6 from 6 to 4
-move 11 from 1 to 6
-move 12 from 6 to 1
-move 1 from 8 to 1
-move 2 from 1 to 3
-move 5 from 4 to 1
-move 1 from 6 to 4
-move 1 from 8 to 3
-move 13 from 3 to 9
-move 3 from 8 to 2
-move 3 from 2 to 7
-move 1 from 3 to 6
-move 3 from 7 to 8
-move 14 from 1 to 3
-move 1 from 1 to 9
-move 6 from 3 to 8
-move 17 from 8 to 6
-move 1 from 3 to 7
-move 1 from 7 to 8
-move 26 from 6 to 7
-move 1 from 1 to 9
-move 3 from 4 to 1
-move 2 from 3 to 8
-move 1 from 8 to 4
-move 14 from 9 to 7
-move 12 from 7 to 3
-move 2 from 1 to 4
-move 2 from 7 to 8
-move 2 from 8 to 3
-move 4 from 9 to 8
-move 1 from 4 to 7
-move 1 from 1 to 3
-move 2 from 4 to 2
-move 24 from 7 to 6
-move 1 from 8 to 1
-move 1 from 7 to 2
-move 1 from 7 to 9
-move 3 from 2 to 9
-move 1 from 1 to 6
-move 5 from 8 to 2
-move 5 from 3 to 4
-move 1 from 2 to 5
-move 3 from 9 to 8
-move 2 from 4 to 9
-move 16 from 6 to 3
-move 14 from 3 to 8
-move 1 from 7 to 9
-move 8 from 6 to 9
-move 4 from 8 to 5
-move 8 from 8 to 3
-move 1 from 5 to 8
-move 1 from 2 to 4
-move 4 from 8 to 7
-move 1 from 5 to 6
-move 12 from 9 to 5
-move 15 from 5 to 8
-move 1 from 6 to 1
-move 2 from 2 to 6
-move 3 from 4 to 2
-move 4 from 2 to 7
-move 8 from 7 to 3
-move 1 from 1 to 4
-move 3 from 6 to 9
-move 16 from 8 to 3
-move 3 from 9 to 4
-move 1 from 8 to 9
-move 2 from 9 to 4
-move 24 from 3 to 8
-move 19 from 8 to 7
-move 2 from 8 to 7
-move 7 from 4 to 5
-move 13 from 7 to 5
-move 4 from 7 to 8
-move 7 from 8 to 1
-move 3 from 5 to 3
-move 3 from 7 to 2
-move 1 from 1 to 4
-move 1 from 7 to 2
-move 3 from 2 to 4
-move 8 from 3 to 1
-move 11 from 1 to 3
-move 12 from 3 to 4
-move 1 from 2 to 5
-move 18 from 3 to 8
-move 3 from 1 to 9
-move 1 from 3 to 5
-move 15 from 5 to 4
-move 4 from 5 to 1
-move 23 from 4 to 6
-move 3 from 1 to 6
-move 13 from 8 to 3
-move 25 from 6 to 2
-move 1 from 9 to 5
-move 5 from 3 to 8
-move 17 from 2 to 8
-move 4 from 4 to 1
-move 1 from 9 to 7
-move 5 from 2 to 6
-move 2 from 2 to 4
-move 1 from 9 to 4
-move 6 from 3 to 9
-move 16 from 8 to 3
-move 2 from 1 to 8
-move 1 from 7 to 4
-move 5 from 4 to 7
-move 1 from 5 to 3
-move 2 from 7 to 1
-move 9 from 8 to 4
-move 3 from 7 to 2
-move 2 from 8 to 3
-move 10 from 4 to 1
-move 1 from 2 to 3
-move 5 from 3 to 7
-move 2 from 8 to 9
-move 2 from 9 to 8
-move 1 from 2 to 1
-move 3 from 9 to 6
-move 2 from 2 to 8
-move 4 from 7 to 3
-move 4 from 8 to 6
-move 1 from 7 to 1
-move 1 from 4 to 8
-move 4 from 3 to 4
-move 4 from 4 to 2
-move 6 from 1 to 2
-move 1 from 4 to 3
-move 5 from 3 to 8
-move 6 from 3 to 8
-move 2 from 2 to 8
-move 3 from 2 to 9
-move 8 from 1 to 6
-move 3 from 2 to 7
-move 2 from 7 to 2
-move 13 from 6 to 5
-move 7 from 5 to 9
-move 3 from 2 to 7
-move 1 from 2 to 9
-move 2 from 5 to 2
-move 3 from 8 to 5
-move 5 from 3 to 4
-move 2 from 2 to 1
-move 9 from 8 to 7
-move 1 from 1 to 8
-move 6 from 5 to 2
-move 4 from 2 to 8
-move 4 from 7 to 1
-move 1 from 2 to 6
-move 5 from 1 to 6
-move 1 from 8 to 2
-move 1 from 2 to 9
-move 13 from 6 to 5
-move 2 from 7 to 2
-move 1 from 8 to 7
-move 4 from 4 to 7
-move 1 from 4 to 1
-move 4 from 8 to 4
-move 6 from 5 to 9
-move 2 from 1 to 4
-move 1 from 8 to 6
-move 11 from 9 to 5
-move 1 from 7 to 8
-move 1 from 8 to 1
-move 1 from 1 to 3
-move 6 from 4 to 8
-move 1 from 8 to 4
-move 1 from 1 to 6
-move 6 from 9 to 7
-move 1 from 4 to 5
-move 3 from 2 to 1
-move 1 from 8 to 2
-move 1 from 3 to 2
-move 20 from 5 to 6
-move 3 from 1 to 6
-move 2 from 2 to 9
-move 3 from 8 to 3
-move 5 from 3 to 8
-move 1 from 1 to 6
-move 2 from 8 to 9
-move 7 from 9 to 5
-move 3 from 5 to 4
-move 3 from 8 to 3
-move 9 from 7 to 9
-move 1 from 8 to 5
-move 7 from 7 to 9
-move 2 from 5 to 2
-move 9 from 9 to 2
-move 1 from 7 to 3
-move 2 from 9 to 1
-move 2 from 5 to 9
-move 2 from 1 to 4
-move 2 from 3 to 7
-move 18 from 6 to 7
-move 7 from 9 to 1
-move 7 from 6 to 8
-move 4 from 4 to 9
-move 4 from 8 to 3
-move 2 from 8 to 2
-move 1 from 8 to 5
-move 1 from 4 to 7
-move 1 from 5 to 1
-move 2 from 9 to 3
-move 12 from 2 to 5
-move 6 from 5 to 6
-move 5 from 7 to 2
-move 3 from 6 to 4
-move 1 from 4 to 7
-move 1 from 4 to 1
-move 2 from 5 to 8
-move 1 from 8 to 2
-move 2 from 9 to 7
-move 8 from 1 to 8
-move 11 from 7 to 1
-move 5 from 8 to 2
-move 7 from 7 to 5
-move 1 from 9 to 4
-move 1 from 7 to 5
-move 7 from 5 to 7
-move 2 from 6 to 1
-move 1 from 8 to 2
-move 12 from 1 to 7
-move 2 from 1 to 2
-move 3 from 8 to 5
-move 3 from 5 to 2
-move 8 from 7 to 3
-move 1 from 3 to 1
-move 3 from 6 to 4
-move 4 from 5 to 6
-move 14 from 2 to 9
-move 3 from 6 to 9
-move 3 from 4 to 2
-move 1 from 1 to 7
-move 1 from 7 to 1
-move 3 from 3 to 5
-move 8 from 7 to 4
-move 1 from 5 to 9
-move 3 from 2 to 4
-move 1 from 3 to 4
-move 4 from 2 to 6
-move 2 from 6 to 7
-move 3 from 5 to 4
-move 16 from 4 to 1
-move 7 from 9 to 8
-move 1 from 5 to 1
-move 3 from 7 to 9
-move 3 from 9 to 4
-move 7 from 1 to 7
-move 6 from 7 to 1
-move 5 from 3 to 1
-move 11 from 9 to 2
-move 3 from 4 to 6
-move 9 from 2 to 8
-move 6 from 3 to 5
-move 2 from 8 to 6
-move 5 from 5 to 3
-move 2 from 7 to 1
-move 3 from 3 to 9
-move 1 from 2 to 4
-move 1 from 5 to 1
-move 13 from 1 to 2
-move 5 from 8 to 6
-move 2 from 3 to 9
-move 2 from 4 to 7
-move 5 from 6 to 9
-move 7 from 9 to 1
-move 3 from 7 to 2
-move 6 from 8 to 6
-move 5 from 6 to 2
-move 2 from 8 to 3
-move 2 from 9 to 4
-move 6 from 2 to 5
-move 1 from 3 to 7`)
+// Part2 rearranges the crates with a CrateMover 9001 and returns the crate on
+// top of each stack.
+func Part2(input string) (string, error) {
+	moves, crates, err := parse(input)
+	if err != nil {
+		return "", err
+	}
+	crates, err = applyMoves(moves, crates)
 	if err != nil {
-		log.Fatal(err)
+		return "", err
+	}
+	return tops(crates)
+}
+
+// tops returns the letter of the crate on top of each stack.
+func tops(crates [][]string) (string, error) {
+	var b strings.Builder
+	for i, c := range crates {
+		if len(c) == 0 {
+			return "", fmt.Errorf("stack %v is empty", i+1)
+		}
+		top := strings.TrimSpace(c[len(c)-1])
+		if len(top) != 3 || top[0] != '[' || top[2] != ']' {
+			return "", fmt.Errorf("stack %v has malformed crate %q", i+1, top)
+		}
+		b.WriteByte(top[1])
 	}
+	return b.String(), nil
 }
 
 func init() {
-	log.SetFlags(log.Flags() | log.Lshortfile)
+	registry.Register(5, 1, Part1)
+	registry.Register(5, 2, Part2)
 }
@@ -0,0 +1,15 @@
+package day5
+
+import (
+	"testing"
+
+	"advent2022/bench"
+)
+
+func BenchmarkPart1(b *testing.B) {
+	bench.File(b, Part1, "testdata/input.txt")
+}
+
+func BenchmarkPart2(b *testing.B) {
+	bench.File(b, Part2, "testdata/input.txt")
+}
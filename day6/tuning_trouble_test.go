@@ -0,0 +1,16 @@
+package day6
+
+import (
+	"testing"
+
+	"advent2022/bench"
+	"advent2022/registry"
+)
+
+func BenchmarkPart1(b *testing.B) {
+	bench.File(b, registry.Int(Part1), "testdata/input.txt")
+}
+
+func BenchmarkPart2(b *testing.B) {
+	bench.File(b, registry.Int(Part2), "testdata/input.txt")
+}
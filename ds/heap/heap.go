@@ -0,0 +1,94 @@
+// Package heap provides a generic binary heap.
+package heap
+
+import "golang.org/x/exp/constraints"
+
+// Heap is a generic implementation of a binary heap. The element that Pop
+// returns is the one that is less than every other according to the heap's
+// less function.
+type Heap[T any] struct {
+	order[T]
+}
+
+// Less reports whether a < b. It orders a min heap.
+func Less[T constraints.Ordered](a, b T) bool {
+	return a < b
+}
+
+// Greater reports whether a > b. It orders a max heap.
+func Greater[T constraints.Ordered](a, b T) bool {
+	return a > b
+}
+
+// New returns an empty heap ordered by less.
+func New[T any](less func(a, b T) bool) *Heap[T] {
+	return &Heap[T]{order[T]{less: less}}
+}
+
+// NewMin returns an empty heap that pops its smallest element first.
+func NewMin[T constraints.Ordered]() *Heap[T] {
+	return New(Less[T])
+}
+
+// NewMax returns an empty heap that pops its largest element first.
+func NewMax[T constraints.Ordered]() *Heap[T] {
+	return New(Greater[T])
+}
+
+// Heapify returns a heap ordered by less holding values in O(n) time. The
+// heap takes ownership of values and reorders it.
+func Heapify[T any](values []T, less func(a, b T) bool) *Heap[T] {
+	h := &Heap[T]{order[T]{values: values, less: less}}
+	for i := len(values)/2 - 1; i >= 0; i-- {
+		h.siftUp(i)
+	}
+	return h
+}
+
+// Len returns the number of elements in the heap.
+func (h *Heap[T]) Len() int {
+	return len(h.values)
+}
+
+// Push inserts an element into the heap.
+func (h *Heap[T]) Push(x T) {
+	h.values = append(h.values, x)
+	h.siftDown(0, len(h.values)-1)
+}
+
+// Peek returns the least element without removing it.
+func (h *Heap[T]) Peek() (T, bool) {
+	if len(h.values) == 0 {
+		var zero T
+		return zero, false
+	}
+	return h.values[0], true
+}
+
+// Pop removes the least element from the heap.
+func (h *Heap[T]) Pop() (T, bool) {
+	if len(h.values) == 0 {
+		var zero T
+		return zero, false
+	}
+	last := h.values[len(h.values)-1]
+	h.values = h.values[:len(h.values)-1]
+	if len(h.values) == 0 {
+		return last, true
+	}
+	first := h.values[0]
+	h.values[0] = last
+	h.siftUp(0)
+	return first, true
+}
+
+// Drain removes every element from the heap and returns them in the order
+// Pop would, least first.
+func (h *Heap[T]) Drain() []T {
+	sorted := make([]T, 0, len(h.values))
+	for len(h.values) > 0 {
+		x, _ := h.Pop()
+		sorted = append(sorted, x)
+	}
+	return sorted
+}
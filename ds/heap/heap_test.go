@@ -0,0 +1,207 @@
+package heap
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+	"testing/quick"
+)
+
+// valid reports whether no element of h is less than its parent.
+func valid[T any](h *Heap[T]) bool {
+	for i := 1; i < len(h.values); i++ {
+		if h.less(h.values[i], h.values[(i-1)/2]) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestEmpty(t *testing.T) {
+	h := NewMin[int]()
+	if got := h.Len(); got != 0 {
+		t.Errorf("Len() = %v, want 0", got)
+	}
+	if _, ok := h.Peek(); ok {
+		t.Errorf("Peek() ok = true, want false")
+	}
+	if _, ok := h.Pop(); ok {
+		t.Errorf("Pop() ok = true, want false")
+	}
+	if got := h.Drain(); len(got) != 0 {
+		t.Errorf("Drain() = %v, want empty", got)
+	}
+}
+
+func TestPushPop(t *testing.T) {
+	h := NewMin[int]()
+	for _, x := range []int{5, 3, 8, 1, 9, 1, 4} {
+		h.Push(x)
+	}
+	if got := h.Len(); got != 7 {
+		t.Errorf("Len() = %v, want 7", got)
+	}
+	if got, ok := h.Peek(); !ok || got != 1 {
+		t.Errorf("Peek() = %v, %v, want 1, true", got, ok)
+	}
+	want := []int{1, 1, 3, 4, 5, 8, 9}
+	for _, w := range want {
+		got, ok := h.Pop()
+		if !ok || got != w {
+			t.Fatalf("Pop() = %v, %v, want %v, true", got, ok, w)
+		}
+	}
+	if _, ok := h.Pop(); ok {
+		t.Errorf("Pop() on empty heap ok = true, want false")
+	}
+}
+
+func TestHeapifyStrings(t *testing.T) {
+	h := Heapify([]string{"pear", "apple", "fig", "banana"}, Less[string])
+	want := []string{"apple", "banana", "fig", "pear"}
+	got := h.Drain()
+	if len(got) != len(want) {
+		t.Fatalf("Drain() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Drain() = %v, want %v", got, want)
+		}
+	}
+	if h.Len() != 0 {
+		t.Errorf("Len() after Drain() = %v, want 0", h.Len())
+	}
+}
+
+func TestPushKeepsInvariant(t *testing.T) {
+	f := func(values []int) bool {
+		h := NewMin[int]()
+		for _, x := range values {
+			h.Push(x)
+			if !valid(h) {
+				return false
+			}
+		}
+		return h.Len() == len(values)
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPopKeepsInvariant(t *testing.T) {
+	f := func(values []int) bool {
+		h := Heapify(append([]int(nil), values...), Less[int])
+		if !valid(h) {
+			return false
+		}
+		for h.Len() > 0 {
+			h.Pop()
+			if !valid(h) {
+				return false
+			}
+		}
+		return true
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestDrainSorts(t *testing.T) {
+	f := func(values []int) bool {
+		want := append([]int(nil), values...)
+		sort.Ints(want)
+		got := Heapify(values, Less[int]).Drain()
+		if len(got) != len(want) {
+			return false
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				return false
+			}
+		}
+		return true
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestInterleaved(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	h := NewMin[int]()
+	var want []int
+	for i := 0; i < 10000; i++ {
+		if r.Intn(3) == 0 && len(want) > 0 {
+			sort.Ints(want)
+			got, ok := h.Pop()
+			if !ok || got != want[0] {
+				t.Fatalf("Pop() = %v, %v, want %v, true", got, ok, want[0])
+			}
+			want = want[1:]
+		} else {
+			x := r.Intn(100)
+			h.Push(x)
+			want = append(want, x)
+		}
+		if !valid(h) {
+			t.Fatalf("heap invariant broken after step %v", i)
+		}
+	}
+}
+
+func TestMax(t *testing.T) {
+	h := NewMax[int]()
+	for _, x := range []int{5, 3, 8, 1, 9} {
+		h.Push(x)
+	}
+	want := []int{9, 8, 5, 3, 1}
+	got := h.Drain()
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Drain() = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestMaxDrainSorts(t *testing.T) {
+	f := func(values []int) bool {
+		want := append([]int(nil), values...)
+		sort.Sort(sort.Reverse(sort.IntSlice(want)))
+		got := Heapify(values, Greater[int]).Drain()
+		for i := range want {
+			if got[i] != want[i] {
+				return false
+			}
+		}
+		return len(got) == len(want)
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestStructs(t *testing.T) {
+	type elf struct {
+		index    int
+		calories int
+	}
+	h := New(func(a, b elf) bool {
+		return a.calories > b.calories
+	})
+	h.Push(elf{1, 6000})
+	h.Push(elf{2, 4000})
+	h.Push(elf{3, 11000})
+	h.Push(elf{4, 24000})
+	want := []int{4, 3, 1, 2}
+	for _, w := range want {
+		got, ok := h.Pop()
+		if !ok || got.index != w {
+			t.Fatalf("Pop() = %v, %v, want elf %v", got, ok, w)
+		}
+		if !valid(h) {
+			t.Fatalf("heap invariant broken after popping elf %v", w)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package heap
+
+// Handle identifies an element pushed onto an Indexed queue.
+type Handle int
+
+type entry[T, P any] struct {
+	handle   Handle
+	value    T
+	priority P
+}
+
+// Indexed is a priority queue that tracks the position of each element so
+// that its priority can be changed, or the element removed, after it was
+// pushed. The element that Pop returns is the one whose priority is less than
+// every other according to the queue's less function.
+type Indexed[T, P any] struct {
+	order[entry[T, P]]
+	// positions maps each handle to the index of its entry, or -1 once the
+	// entry has left the queue.
+	positions []int
+}
+
+// NewIndexed returns an empty queue ordered by less.
+func NewIndexed[T, P any](less func(a, b P) bool) *Indexed[T, P] {
+	q := &Indexed[T, P]{}
+	q.less = func(a, b entry[T, P]) bool {
+		return less(a.priority, b.priority)
+	}
+	q.moved = func(e entry[T, P], i int) {
+		q.positions[e.handle] = i
+	}
+	return q
+}
+
+// Len returns the number of elements in the queue.
+func (q *Indexed[T, P]) Len() int {
+	return len(q.values)
+}
+
+// Push inserts value with the given priority and returns its handle.
+func (q *Indexed[T, P]) Push(value T, priority P) Handle {
+	h := Handle(len(q.positions))
+	q.positions = append(q.positions, len(q.values))
+	q.values = append(q.values, entry[T, P]{h, value, priority})
+	q.siftDown(0, len(q.values)-1)
+	return h
+}
+
+// Peek returns the least element and its priority without removing it.
+func (q *Indexed[T, P]) Peek() (T, P, bool) {
+	if len(q.values) == 0 {
+		var value T
+		var priority P
+		return value, priority, false
+	}
+	e := q.values[0]
+	return e.value, e.priority, true
+}
+
+// Pop removes the least element from the queue.
+func (q *Indexed[T, P]) Pop() (T, P, bool) {
+	if len(q.values) == 0 {
+		var value T
+		var priority P
+		return value, priority, false
+	}
+	e := q.values[0]
+	q.remove(0)
+	return e.value, e.priority, true
+}
+
+// Contains reports whether the element with handle h is still in the queue.
+func (q *Indexed[T, P]) Contains(h Handle) bool {
+	return h >= 0 && int(h) < len(q.positions) && q.positions[h] >= 0
+}
+
+// Get returns the element with handle h and its priority.
+func (q *Indexed[T, P]) Get(h Handle) (T, P, bool) {
+	if !q.Contains(h) {
+		var value T
+		var priority P
+		return value, priority, false
+	}
+	e := q.values[q.positions[h]]
+	return e.value, e.priority, true
+}
+
+// Update changes the priority of the element with handle h. It reports
+// whether the element was in the queue.
+func (q *Indexed[T, P]) Update(h Handle, priority P) bool {
+	if !q.Contains(h) {
+		return false
+	}
+	i := q.positions[h]
+	old := q.values[i]
+	q.values[i].priority = priority
+	if q.less(q.values[i], old) {
+		q.siftDown(0, i)
+	} else {
+		q.siftUp(i)
+	}
+	return true
+}
+
+// Remove removes the element with handle h from the queue. It reports
+// whether the element was in the queue.
+func (q *Indexed[T, P]) Remove(h Handle) (T, bool) {
+	if !q.Contains(h) {
+		var value T
+		return value, false
+	}
+	i := q.positions[h]
+	value := q.values[i].value
+	q.remove(i)
+	return value, true
+}
+
+// remove takes the entry at index i out of the queue.
+func (q *Indexed[T, P]) remove(i int) {
+	q.positions[q.values[i].handle] = -1
+	last := q.values[len(q.values)-1]
+	q.values = q.values[:len(q.values)-1]
+	if i == len(q.values) {
+		return
+	}
+	q.set(i, last)
+	// The last entry may belong above or below i, so sift it both ways.
+	q.siftUp(i)
+	q.siftDown(0, q.positions[last.handle])
+}
@@ -0,0 +1,158 @@
+package heap
+
+import (
+	"container/heap"
+	"math/rand"
+	"testing"
+)
+
+// refItem is an element of refQueue, the container/heap based queue that
+// Indexed is checked against.
+type refItem struct {
+	value    int
+	priority int
+	index    int
+}
+
+type refQueue []*refItem
+
+func (q refQueue) Len() int           { return len(q) }
+func (q refQueue) Less(i, j int) bool { return q[i].priority < q[j].priority }
+func (q refQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *refQueue) Push(x any) {
+	item := x.(*refItem)
+	item.index = len(*q)
+	*q = append(*q, item)
+}
+
+func (q *refQueue) Pop() any {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	item.index = -1
+	return item
+}
+
+// validIndexed reports whether no entry of q is less than its parent and
+// every live handle points at its entry.
+func validIndexed[T, P any](q *Indexed[T, P]) bool {
+	for i := 1; i < len(q.values); i++ {
+		if q.less(q.values[i], q.values[(i-1)/2]) {
+			return false
+		}
+	}
+	for i, e := range q.values {
+		if q.positions[e.handle] != i {
+			return false
+		}
+	}
+	return true
+}
+
+func TestIndexedAgainstContainerHeap(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	q := NewIndexed[int](Less[int])
+	ref := &refQueue{}
+	// live maps each handle still in q to its item in ref.
+	live := map[Handle]*refItem{}
+	// handles maps each value to the handle it was pushed with.
+	handles := map[int]Handle{}
+	// Priorities are made unique so that both queues pop the same element.
+	priority := func(step int) int {
+		return r.Intn(1000)*100000 + step
+	}
+	for step := 0; step < 20000; step++ {
+		switch op := r.Intn(10); {
+		case op < 4 || q.Len() == 0:
+			value, p := step, priority(step)
+			h := q.Push(value, p)
+			item := &refItem{value: value, priority: p}
+			heap.Push(ref, item)
+			live[h] = item
+			handles[value] = h
+		case op < 6:
+			value, p, ok := q.Pop()
+			want := heap.Pop(ref).(*refItem)
+			if !ok || value != want.value || p != want.priority {
+				t.Fatalf("step %v: Pop() = %v, %v, %v, want %v, %v", step, value, p, ok, want.value, want.priority)
+			}
+			h := handles[value]
+			if q.Contains(h) {
+				t.Fatalf("step %v: Contains(%v) = true after Pop", step, h)
+			}
+			delete(live, h)
+		case op < 8:
+			h := Handle(r.Intn(len(handles)))
+			p := priority(step)
+			item, ok := live[h]
+			if got := q.Update(h, p); got != ok {
+				t.Fatalf("step %v: Update(%v) = %v, want %v", step, h, got, ok)
+			}
+			if ok {
+				item.priority = p
+				heap.Fix(ref, item.index)
+			}
+		default:
+			h := Handle(r.Intn(len(handles)))
+			item, ok := live[h]
+			value, got := q.Remove(h)
+			if got != ok {
+				t.Fatalf("step %v: Remove(%v) ok = %v, want %v", step, h, got, ok)
+			}
+			if ok {
+				if value != item.value {
+					t.Fatalf("step %v: Remove(%v) = %v, want %v", step, h, value, item.value)
+				}
+				heap.Remove(ref, item.index)
+				delete(live, h)
+			}
+		}
+		if q.Len() != ref.Len() {
+			t.Fatalf("step %v: Len() = %v, want %v", step, q.Len(), ref.Len())
+		}
+		if !validIndexed(q) {
+			t.Fatalf("step %v: queue invariant broken", step)
+		}
+	}
+}
+
+func TestIndexedDecreaseKey(t *testing.T) {
+	q := NewIndexed[string](Less[int])
+	a := q.Push("a", 5)
+	b := q.Push("b", 3)
+	c := q.Push("c", 8)
+	if !q.Update(c, 1) {
+		t.Fatalf("Update(c) = false, want true")
+	}
+	if value, priority, _ := q.Peek(); value != "c" || priority != 1 {
+		t.Errorf("Peek() = %v, %v, want c, 1", value, priority)
+	}
+	if value, ok := q.Remove(b); !ok || value != "b" {
+		t.Errorf("Remove(b) = %v, %v, want b, true", value, ok)
+	}
+	if q.Contains(b) {
+		t.Errorf("Contains(b) = true after Remove, want false")
+	}
+	if q.Update(b, 0) {
+		t.Errorf("Update(b) = true after Remove, want false")
+	}
+	if _, priority, ok := q.Get(a); !ok || priority != 5 {
+		t.Errorf("Get(a) priority = %v, %v, want 5, true", priority, ok)
+	}
+	var order []string
+	for q.Len() > 0 {
+		value, _, _ := q.Pop()
+		order = append(order, value)
+	}
+	if len(order) != 2 || order[0] != "c" || order[1] != "a" {
+		t.Errorf("pop order = %v, want [c a]", order)
+	}
+	if q.Contains(a) || q.Contains(Handle(-1)) || q.Contains(Handle(10)) {
+		t.Errorf("Contains reports handles that are not in the queue")
+	}
+}
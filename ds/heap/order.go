@@ -0,0 +1,58 @@
+package heap
+
+// order is a slice kept in binary heap order by less. It holds the sift
+// routines shared by Heap and Indexed.
+type order[E any] struct {
+	values []E
+	less   func(a, b E) bool
+	// moved, if not nil, is called with each element that set stores and
+	// its new index.
+	moved func(e E, i int)
+}
+
+// set stores e at index i.
+func (h *order[E]) set(i int, e E) {
+	h.values[i] = e
+	if h.moved != nil {
+		h.moved(e, i)
+	}
+}
+
+// siftDown moves the item at index j towards the root at index i until its
+// parent is not greater than it.
+func (h *order[E]) siftDown(i, j int) {
+	item := h.values[j]
+	for i < j {
+		parentIndex := (j - 1) >> 1
+		parent := h.values[parentIndex]
+		if h.less(item, parent) {
+			h.set(j, parent)
+			j = parentIndex
+			continue
+		}
+		break
+	}
+	h.set(j, item)
+}
+
+// siftUp restores the order below index after the item there was replaced.
+func (h *order[E]) siftUp(index int) {
+	// Move item to a leaf node, swapping with the lesser of the two
+	// kids nodes on the way there.
+	originalIndex := index
+	item := h.values[index]
+	for kid := 2*index + 1; kid < len(h.values); kid = 2*index + 1 {
+		right := kid + 1
+		if right < len(h.values) {
+			if !h.less(h.values[kid], h.values[right]) {
+				kid = right
+			}
+		}
+		h.set(index, h.values[kid])
+		index = kid
+	}
+	h.set(index, item)
+	// Item was swapped into a leaf irrespective of whether that was right.
+	// Now, move it back up where it should go.
+	h.siftDown(originalIndex, index)
+}
@@ -2,4 +2,4 @@ module advent2022
 
 go 1.19
 
-require golang.org/x/exp v0.0.0-20221126150942-6ab00d035af9 // indirect
+require golang.org/x/exp v0.0.0-20221126150942-6ab00d035af9
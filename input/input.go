@@ -0,0 +1,65 @@
+// Package input loads puzzle inputs from files and stdin.
+package input
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Dir is the directory that holds each day's default input, named
+// day<N>.txt.
+var Dir = "inputs"
+
+// Stdin is the path that selects standard input.
+const Stdin = "-"
+
+// Load returns the puzzle input for day. It reads path if it is set, stdin if
+// path is Stdin, and the day's file in Dir otherwise.
+func Load(day int, path string) (string, error) {
+	r, err := Open(day, path)
+	if err != nil {
+		return "", err
+	}
+	defer r.Close()
+	return Read(r)
+}
+
+// Open returns a reader of the puzzle input for day, chosen the same way as
+// Load. The caller must close it.
+func Open(day int, path string) (io.ReadCloser, error) {
+	switch path {
+	case Stdin:
+		return io.NopCloser(os.Stdin), nil
+	case "":
+		path = Default(day)
+	}
+	return os.Open(path)
+}
+
+// Default returns the path of the default input for day.
+func Default(day int) string {
+	return filepath.Join(Dir, fmt.Sprintf("day%v.txt", day))
+}
+
+// ReadFile returns the puzzle input stored in the named file.
+func ReadFile(name string) (string, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	return Read(f)
+}
+
+// Read returns the puzzle input read from r. The trailing newline that ends
+// the last line is removed so that solvers see the same text as the puzzle.
+func Read(r io.Reader) (string, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return "", fmt.Errorf("read input: %v", err)
+	}
+	return strings.TrimRight(string(b), "\n"), nil
+}
@@ -0,0 +1,72 @@
+// Package registry maps each puzzle day and part to the solver that answers
+// it. Day packages register themselves from their init functions.
+package registry
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// Solver computes the answer to one part of a day's puzzle.
+type Solver func(input string) (string, error)
+
+type key struct {
+	day  int
+	part int
+}
+
+var solvers = map[key]Solver{}
+
+// Register records s as the solver for the given day and part. It panics if
+// that day and part already have a solver.
+func Register(day, part int, s Solver) {
+	k := key{day, part}
+	if _, ok := solvers[k]; ok {
+		panic(fmt.Sprintf("registry: day %v part %v registered twice", day, part))
+	}
+	solvers[k] = s
+}
+
+// Lookup returns the solver for the given day and part.
+func Lookup(day, part int) (Solver, bool) {
+	s, ok := solvers[key{day, part}]
+	return s, ok
+}
+
+// Days returns every day with at least one solver, in ascending order.
+func Days() []int {
+	seen := map[int]bool{}
+	var days []int
+	for k := range solvers {
+		if !seen[k.day] {
+			seen[k.day] = true
+			days = append(days, k.day)
+		}
+	}
+	sort.Ints(days)
+	return days
+}
+
+// Parts returns the parts of day that have a solver, in ascending order.
+func Parts(day int) []int {
+	var parts []int
+	for k := range solvers {
+		if k.day == day {
+			parts = append(parts, k.part)
+		}
+	}
+	sort.Ints(parts)
+	return parts
+}
+
+// Int adapts a function with an integer answer into a Solver.
+func Int(f func(input string) (int, error)) Solver {
+	return func(input string) (string, error) {
+		n, err := f(input)
+		if err != nil {
+			return "", err
+		}
+		return strconv.Itoa(n), nil
+	}
+}